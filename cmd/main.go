@@ -1,16 +1,35 @@
 package main
 
 import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
 	"github.com/AthulKrishna2501/zyra-admin-service/internals/app/config"
 	"github.com/AthulKrishna2501/zyra-admin-service/internals/app/grpc"
 	"github.com/AthulKrishna2501/zyra-admin-service/internals/app/healthcheck"
+	"github.com/AthulKrishna2501/zyra-admin-service/internals/app/livefeed"
+	"github.com/AthulKrishna2501/zyra-admin-service/internals/app/middleware"
 	"github.com/AthulKrishna2501/zyra-admin-service/internals/core/database"
+	"github.com/AthulKrishna2501/zyra-admin-service/internals/core/events"
 	"github.com/AthulKrishna2501/zyra-admin-service/internals/core/repository"
 	"github.com/AthulKrishna2501/zyra-admin-service/internals/logger"
 	"github.com/gin-gonic/gin"
 )
 
+// shutdownTimeout bounds how long open HTTP requests get to finish once
+// the service is asked to stop.
+const shutdownTimeout = 10 * time.Second
+
 func main() {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
 	log := logger.NewLogrusLogger()
 	configEnv, err := config.LoadConfig()
 	if err != nil {
@@ -20,7 +39,7 @@ func main() {
 
 	config.InitRedis()
 
-	db := database.ConnectDatabase(configEnv)
+	db := database.ConnectDatabase(configEnv, log)
 	if db == nil {
 		log.Error("Failed to connect to database")
 		return
@@ -29,7 +48,10 @@ func main() {
 
 	AdminRepo := repository.NewAdminRepository(db)
 
-	err = grpc.StartgRPCServer(AdminRepo, log)
+	feed := events.NewBus()
+	go events.Listen(ctx, configEnv.DB_URL, feed, log)
+
+	err = grpc.StartgRPCServer(ctx, AdminRepo, feed, log)
 
 	if err != nil {
 		log.Error("Failed to start gRPC server", err.Error())
@@ -40,6 +62,25 @@ func main() {
 	log.Info("HTTP Server started on port 3006")
 
 	router.GET("/health", healthcheck.HealthCheckHandler)
-	router.Run(":3006")
+	router.GET("/admin/live-feed", middleware.AdminAuth(configEnv.JWT_SECRET_KEY), livefeed.Handler(feed))
 
+	// Requests share ctx so open live-feed streams end on shutdown instead
+	// of holding it up until the timeout.
+	server := &http.Server{
+		Addr:        ":3006",
+		Handler:     router,
+		BaseContext: func(net.Listener) context.Context { return ctx },
+	}
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+		defer cancel()
+		if err := server.Shutdown(shutdownCtx); err != nil {
+			log.Error("Failed to shut down HTTP server", err.Error())
+		}
+	}()
+
+	if err := server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		log.Error("Failed to serve HTTP", err.Error())
+	}
 }
@@ -7,8 +7,9 @@ import (
 )
 
 type Config struct {
-	PORT   string `mapstructure:"PORT"`
-	DB_URL string `mapstructure:"DB_URL"`
+	PORT           string `mapstructure:"PORT"`
+	DB_URL         string `mapstructure:"DB_URL"`
+	JWT_SECRET_KEY string `mapstructure:"JWT_SECRET_KEY"`
 }
 
 func LoadConfig() (cfg Config, err error) {
@@ -1,16 +1,20 @@
 package grpc
 
 import (
+	"context"
 	"net"
 
 	"github.com/AthulKrishna2501/proto-repo/admin"
+	"github.com/AthulKrishna2501/zyra-admin-service/internals/core/events"
 	"github.com/AthulKrishna2501/zyra-admin-service/internals/core/repository"
 	"github.com/AthulKrishna2501/zyra-admin-service/internals/core/services"
 	"github.com/AthulKrishna2501/zyra-admin-service/internals/logger"
 	"google.golang.org/grpc"
 )
 
-func StartgRPCServer(AdminRepo repository.AdminRepository, log logger.Logger) error {
+// StartgRPCServer serves the admin service and runs its background workers
+// until ctx is cancelled, then stops the workers and drains the server.
+func StartgRPCServer(ctx context.Context, AdminRepo repository.AdminRepository, feed *events.Bus, log logger.Logger) error {
 	go func() {
 		lis, err := net.Listen("tcp", ":5005")
 		if err != nil {
@@ -22,9 +26,19 @@ func StartgRPCServer(AdminRepo repository.AdminRepository, log logger.Logger) er
 			grpc.MaxRecvMsgSize(1024*1024*100),
 			grpc.MaxSendMsgSize(1024*1024*100),
 		)
-		adminService := services.NewAdminService(AdminRepo, log)
+		adminService := services.NewAdminService(AdminRepo, feed, log)
 		admin.RegisterAdminServiceServer(grpcServer, adminService)
 
+		go adminService.StartAutoApprovalWorker(ctx)
+		go adminService.StartDashboardReconciler(ctx)
+		go adminService.StartAnomalyDetector(ctx)
+
+		go func() {
+			<-ctx.Done()
+			log.Info("Stopping gRPC server")
+			grpcServer.GracefulStop()
+		}()
+
 		log.Info("gRPC Server started on port 5005")
 		if err := grpcServer.Serve(lis); err != nil {
 			log.Error("Failed to serve gRPC: %v", err)
@@ -0,0 +1,66 @@
+package livefeed
+
+import (
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/AthulKrishna2501/zyra-admin-service/internals/core/events"
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	subscriberBuffer = 256
+
+	// heartbeatInterval keeps idle connections from being closed by
+	// proxies between the dashboard and the service.
+	heartbeatInterval = 15 * time.Second
+)
+
+// Handler serves the live feed as server-sent events. Each event is named
+// after its type and carries the event as JSON. The optional types query
+// parameter is a comma separated list of event types to receive.
+func Handler(bus *events.Bus) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var types []string
+		if raw := c.Query("types"); raw != "" {
+			types = strings.Split(raw, ",")
+		}
+
+		filter, err := events.ParseFilter(types)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		feed, unsubscribe := bus.Subscribe(subscriberBuffer)
+		defer unsubscribe()
+
+		heartbeat := time.NewTicker(heartbeatInterval)
+		defer heartbeat.Stop()
+
+		c.Header("Content-Type", "text/event-stream")
+		c.Header("Cache-Control", "no-cache")
+		c.Header("Connection", "keep-alive")
+		c.Header("X-Accel-Buffering", "no")
+
+		c.Stream(func(w io.Writer) bool {
+			select {
+			case <-c.Request.Context().Done():
+				return false
+			case <-heartbeat.C:
+				_, err := io.WriteString(w, ": keep-alive\n\n")
+				return err == nil
+			case event, ok := <-feed:
+				if !ok {
+					return false
+				}
+				if filter.Match(event) {
+					c.SSEvent(event.Type, event)
+				}
+				return true
+			}
+		})
+	}
+}
@@ -0,0 +1,62 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+func TestAdminAuth(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	const secret = "test-secret"
+
+	sign := func(method jwt.SigningMethod, key interface{}, claims jwt.MapClaims) string {
+		token, err := jwt.NewWithClaims(method, claims).SignedString(key)
+		if err != nil {
+			t.Fatalf("failed to sign token: %v", err)
+		}
+		return "Bearer " + token
+	}
+	valid := func(role string) jwt.MapClaims {
+		return jwt.MapClaims{"role": role, "exp": time.Now().Add(time.Hour).Unix()}
+	}
+
+	tests := []struct {
+		name   string
+		secret string
+		header string
+		want   int
+	}{
+		{"admin", secret, sign(jwt.SigningMethodHS256, []byte(secret), valid("admin")), http.StatusOK},
+		{"client", secret, sign(jwt.SigningMethodHS256, []byte(secret), valid("client")), http.StatusForbidden},
+		{"no header", secret, "", http.StatusUnauthorized},
+		{"not bearer", secret, "Basic YWRtaW46YWRtaW4=", http.StatusUnauthorized},
+		{"wrong secret", secret, sign(jwt.SigningMethodHS256, []byte("other"), valid("admin")), http.StatusUnauthorized},
+		{"expired", secret, sign(jwt.SigningMethodHS256, []byte(secret), jwt.MapClaims{"role": "admin", "exp": time.Now().Add(-time.Minute).Unix()}), http.StatusUnauthorized},
+		{"no expiry", secret, sign(jwt.SigningMethodHS256, []byte(secret), jwt.MapClaims{"role": "admin"}), http.StatusUnauthorized},
+		{"unsigned", secret, sign(jwt.SigningMethodNone, jwt.UnsafeAllowNoneSignatureType, valid("admin")), http.StatusUnauthorized},
+		{"no secret configured", "", sign(jwt.SigningMethodHS256, []byte(""), valid("admin")), http.StatusUnauthorized},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router := gin.New()
+			router.GET("/admin", AdminAuth(tt.secret), func(c *gin.Context) { c.Status(http.StatusOK) })
+
+			req := httptest.NewRequest(http.MethodGet, "/admin", nil)
+			if tt.header != "" {
+				req.Header.Set("Authorization", tt.header)
+			}
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, req)
+
+			if rec.Code != tt.want {
+				t.Errorf("status = %d, want %d", rec.Code, tt.want)
+			}
+		})
+	}
+}
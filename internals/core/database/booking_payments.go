@@ -0,0 +1,22 @@
+package database
+
+import (
+	"github.com/AthulKrishna2501/zyra-admin-service/internals/logger"
+	"gorm.io/gorm"
+)
+
+// migrateBookingPaymentStatus backfills bookings.payment_status, which
+// AutoMigrate adds as "unpaid". Before the column existed a booking past
+// the pending status had been paid for, so the open and completed ones are
+// marked paid. From now on the booking service sets the column when the
+// client pays.
+func migrateBookingPaymentStatus(tx *gorm.DB, log logger.Logger) error {
+	result := tx.Exec(`UPDATE bookings SET payment_status = 'paid'
+		WHERE payment_status = 'unpaid' AND status NOT IN ('pending', 'cancelled')`)
+	if result.Error != nil {
+		return result.Error
+	}
+
+	log.Info("Database: marked existing bookings as paid", result.RowsAffected)
+	return nil
+}
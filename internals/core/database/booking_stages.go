@@ -0,0 +1,51 @@
+package database
+
+import (
+	"github.com/AthulKrishna2501/zyra-admin-service/internals/logger"
+	"gorm.io/gorm"
+)
+
+// The booking stage trigger stamps the time a booking's approval and fund
+// release flags turn true, and clears it if a flag is turned off again.
+// Bookings are written by other services, so a trigger is the one place
+// every write passes through.
+var bookingStageStatements = []string{
+	`CREATE OR REPLACE FUNCTION admin_booking_stage_times() RETURNS trigger AS $$
+	BEGIN
+		IF NEW.is_vendor_approved AND (TG_OP = 'INSERT' OR NOT OLD.is_vendor_approved) THEN
+			NEW.vendor_approved_at := COALESCE(NEW.vendor_approved_at, NOW());
+		ELSIF NOT NEW.is_vendor_approved THEN
+			NEW.vendor_approved_at := NULL;
+		END IF;
+		IF NEW.is_client_approved AND (TG_OP = 'INSERT' OR NOT OLD.is_client_approved) THEN
+			NEW.client_approved_at := COALESCE(NEW.client_approved_at, NOW());
+		ELSIF NOT NEW.is_client_approved THEN
+			NEW.client_approved_at := NULL;
+		END IF;
+		IF NEW.is_fund_released AND (TG_OP = 'INSERT' OR NOT OLD.is_fund_released) THEN
+			NEW.fund_released_at := COALESCE(NEW.fund_released_at, NOW());
+		ELSIF NOT NEW.is_fund_released THEN
+			NEW.fund_released_at := NULL;
+		END IF;
+		RETURN NEW;
+	END;
+	$$ LANGUAGE plpgsql`,
+
+	`DROP TRIGGER IF EXISTS admin_booking_stages ON bookings`,
+	`CREATE TRIGGER admin_booking_stages
+		BEFORE INSERT OR UPDATE OF is_vendor_approved, is_client_approved, is_fund_released ON bookings
+		FOR EACH ROW EXECUTE FUNCTION admin_booking_stage_times()`,
+}
+
+// migrateBookingStageTrigger installs the booking stage trigger. Bookings
+// that passed a stage before it existed keep a NULL stage time and are left
+// out of the stage medians.
+func migrateBookingStageTrigger(tx *gorm.DB, log logger.Logger) error {
+	for _, stmt := range bookingStageStatements {
+		if err := tx.Exec(stmt).Error; err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,103 @@
+package database
+
+import (
+	"context"
+
+	"github.com/AthulKrishna2501/zyra-admin-service/internals/core/models"
+	"github.com/AthulKrishna2501/zyra-admin-service/internals/core/repository"
+	"github.com/AthulKrishna2501/zyra-admin-service/internals/logger"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// The trigger functions append a delta for every change to users and
+// bookings inside the writing transaction. Counter names, roles and the
+// cancelled status match the constants in models. A cancelled booking still
+// counts as a booking but not as revenue, so moving a booking into or out of
+// cancelled moves its price out of or back into the revenue.
+var dashboardCounterStatements = []string{
+	`CREATE OR REPLACE FUNCTION admin_dashboard_count_users() RETURNS trigger AS $$
+	BEGIN
+		IF TG_OP = 'UPDATE' AND OLD.role IS NOT DISTINCT FROM NEW.role THEN
+			RETURN NULL;
+		END IF;
+		IF TG_OP IN ('UPDATE', 'DELETE') AND OLD.role IN ('vendor', 'client') THEN
+			INSERT INTO dashboard_counter_deltas (name, delta, created_at)
+			VALUES (CASE OLD.role WHEN 'vendor' THEN 'total_vendors' ELSE 'total_clients' END, -1, NOW());
+		END IF;
+		IF TG_OP IN ('INSERT', 'UPDATE') AND NEW.role IN ('vendor', 'client') THEN
+			INSERT INTO dashboard_counter_deltas (name, delta, created_at)
+			VALUES (CASE NEW.role WHEN 'vendor' THEN 'total_vendors' ELSE 'total_clients' END, 1, NOW());
+		END IF;
+		RETURN NULL;
+	END;
+	$$ LANGUAGE plpgsql`,
+
+	`CREATE OR REPLACE FUNCTION admin_dashboard_count_bookings() RETURNS trigger AS $$
+	DECLARE
+		old_revenue bigint := 0;
+		new_revenue bigint := 0;
+	BEGIN
+		IF TG_OP IN ('UPDATE', 'DELETE') AND OLD.status IS DISTINCT FROM 'cancelled' THEN
+			old_revenue := COALESCE(OLD.price, 0);
+		END IF;
+		IF TG_OP IN ('INSERT', 'UPDATE') AND NEW.status IS DISTINCT FROM 'cancelled' THEN
+			new_revenue := COALESCE(NEW.price, 0);
+		END IF;
+
+		IF TG_OP = 'INSERT' THEN
+			INSERT INTO dashboard_counter_deltas (name, delta, created_at) VALUES ('total_bookings', 1, NOW());
+		ELSIF TG_OP = 'DELETE' THEN
+			INSERT INTO dashboard_counter_deltas (name, delta, created_at) VALUES ('total_bookings', -1, NOW());
+		END IF;
+		IF new_revenue <> old_revenue THEN
+			INSERT INTO dashboard_counter_deltas (name, delta, created_at) VALUES ('total_revenue', new_revenue - old_revenue, NOW());
+		END IF;
+		RETURN NULL;
+	END;
+	$$ LANGUAGE plpgsql`,
+
+	`DROP TRIGGER IF EXISTS admin_dashboard_users ON users`,
+	`CREATE TRIGGER admin_dashboard_users AFTER INSERT OR DELETE OR UPDATE OF role ON users
+		FOR EACH ROW EXECUTE FUNCTION admin_dashboard_count_users()`,
+
+	`DROP TRIGGER IF EXISTS admin_dashboard_bookings ON bookings`,
+	`CREATE TRIGGER admin_dashboard_bookings AFTER INSERT OR DELETE OR UPDATE OF price, status ON bookings
+		FOR EACH ROW EXECUTE FUNCTION admin_dashboard_count_bookings()`,
+}
+
+// migrateDashboardCounters installs the counter triggers and seeds the
+// counters from a full count. Creating the triggers locks users and bookings
+// against writes until the migration commits, so the seed cannot miss a
+// row; this happens once, not on every start.
+func migrateDashboardCounters(tx *gorm.DB, log logger.Logger) error {
+	for _, stmt := range dashboardCounterStatements {
+		if err := tx.Exec(stmt).Error; err != nil {
+			return err
+		}
+	}
+
+	if err := tx.Where("TRUE").Delete(&models.DashboardCounterDelta{}).Error; err != nil {
+		return err
+	}
+
+	txRepo := &repository.AdminStorage{DB: tx}
+	stats, err := txRepo.CountDashboardStats(context.Background())
+	if err != nil {
+		return err
+	}
+
+	counters := []models.DashboardCounter{
+		{Name: models.CounterTotalVendors, Value: int64(stats.TotalVendors)},
+		{Name: models.CounterTotalClients, Value: int64(stats.TotalClients)},
+		{Name: models.CounterTotalBookings, Value: int64(stats.TotalBookings)},
+		{Name: models.CounterTotalRevenue, Value: stats.TotalRevenue},
+	}
+
+	log.Info("Database: seeded dashboard counters", stats.TotalVendors, stats.TotalClients, stats.TotalBookings, stats.TotalRevenue)
+
+	return tx.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "name"}},
+		DoUpdates: clause.AssignmentColumns([]string{"value", "updated_at"}),
+	}).Create(&counters).Error
+}
@@ -0,0 +1,63 @@
+package database
+
+import (
+	"context"
+
+	"github.com/AthulKrishna2501/zyra-admin-service/internals/core/repository"
+	"github.com/AthulKrishna2501/zyra-admin-service/internals/logger"
+	"gorm.io/gorm"
+)
+
+// The revenue counter follows the all-time count, which sums the price of
+// every booking, cancelled ones included. A status change therefore no
+// longer moves revenue; only price changes do.
+var dashboardRevenueStatements = []string{
+	`CREATE OR REPLACE FUNCTION admin_dashboard_count_bookings() RETURNS trigger AS $$
+	DECLARE
+		old_revenue bigint := 0;
+		new_revenue bigint := 0;
+	BEGIN
+		IF TG_OP IN ('UPDATE', 'DELETE') THEN
+			old_revenue := COALESCE(OLD.price, 0);
+		END IF;
+		IF TG_OP IN ('INSERT', 'UPDATE') THEN
+			new_revenue := COALESCE(NEW.price, 0);
+		END IF;
+
+		IF TG_OP = 'INSERT' THEN
+			INSERT INTO dashboard_counter_deltas (name, delta, created_at) VALUES ('total_bookings', 1, NOW());
+		ELSIF TG_OP = 'DELETE' THEN
+			INSERT INTO dashboard_counter_deltas (name, delta, created_at) VALUES ('total_bookings', -1, NOW());
+		END IF;
+		IF new_revenue <> old_revenue THEN
+			INSERT INTO dashboard_counter_deltas (name, delta, created_at) VALUES ('total_revenue', new_revenue - old_revenue, NOW());
+		END IF;
+		RETURN NULL;
+	END;
+	$$ LANGUAGE plpgsql`,
+
+	`DROP TRIGGER IF EXISTS admin_dashboard_bookings ON bookings`,
+	`CREATE TRIGGER admin_dashboard_bookings AFTER INSERT OR DELETE OR UPDATE OF price ON bookings
+		FOR EACH ROW EXECUTE FUNCTION admin_dashboard_count_bookings()`,
+}
+
+// migrateDashboardRevenue replaces the booking counter trigger and corrects
+// the revenue counter for the cancelled bookings it used to leave out.
+// Recreating the trigger locks bookings against writes until the migration
+// commits, so the correction is exact.
+func migrateDashboardRevenue(tx *gorm.DB, log logger.Logger) error {
+	for _, stmt := range dashboardRevenueStatements {
+		if err := tx.Exec(stmt).Error; err != nil {
+			return err
+		}
+	}
+
+	txRepo := &repository.AdminStorage{DB: tx}
+	before, after, err := txRepo.ReconcileDashboardCounters(context.Background())
+	if err != nil {
+		return err
+	}
+
+	log.Info("Database: corrected dashboard revenue counter", before.TotalRevenue, after.TotalRevenue)
+	return nil
+}
@@ -1,24 +1,24 @@
 package database
 
 import (
-	"log"
-
 	"github.com/AthulKrishna2501/zyra-admin-service/internals/app/config"
 	"github.com/AthulKrishna2501/zyra-admin-service/internals/core/models"
+	"github.com/AthulKrishna2501/zyra-admin-service/internals/core/repository"
+	"github.com/AthulKrishna2501/zyra-admin-service/internals/logger"
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
 )
 
-func ConnectDatabase(env config.Config) *gorm.DB {
+func ConnectDatabase(env config.Config, log logger.Logger) *gorm.DB {
 	db, err := gorm.Open(postgres.Open(env.DB_URL), &gorm.Config{})
 	if err != nil {
-		log.Fatal("Failed to connect to database", err)
+		log.Error("Failed to connect to database", err.Error())
 		return nil
 	}
 
-	err = AutoMigrate(db)
+	err = AutoMigrate(db, log)
 	if err != nil {
-		log.Fatal("Error in automigration", err)
+		log.Error("Error in automigration", err.Error())
 		return nil
 
 	}
@@ -26,11 +26,73 @@ func ConnectDatabase(env config.Config) *gorm.DB {
 	return db
 }
 
-func AutoMigrate(db *gorm.DB) error {
-	return db.AutoMigrate(
+func AutoMigrate(db *gorm.DB, log logger.Logger) error {
+	err := db.AutoMigrate(
 		&models.AdminWallet{},
 		&models.Booking{},
 		&models.AdminWalletTransaction{},
 		&models.FundRelease{},
+		&models.HiddenVendorCategory{},
+		&models.PayoutHold{},
+		&models.VendorVerification{},
+		&models.VerificationDocument{},
+		&models.VerificationCheck{},
+		&models.DataErasure{},
+		&models.RoleChange{},
+		&models.Category{},
+		&models.CategoryRequest{},
+		&models.CategoryRequestDecision{},
+		&models.CategoryRevocation{},
+		&models.AutoApprovalSettings{},
+		&models.AutoApprovalEvaluation{},
+		&models.DashboardCounter{},
+		&models.DashboardCounterDelta{},
+		&models.AnomalySettings{},
+		&models.AnomalyAlert{},
 	)
+	if err != nil {
+		return err
+	}
+
+	return runMigrations(db, log)
+}
+
+// migrateCategoryNameIndex enforces case-insensitive category names per
+// parent. GORM tags cannot express the expression index, so it is created
+// by hand. Names are normalized first, and names that then clash with an
+// older sibling get a " (n)" suffix so the index can be built; the renamed
+// categories are logged for an admin to merge or rename.
+func migrateCategoryNameIndex(tx *gorm.DB, log logger.Logger) error {
+	err := tx.Exec(`UPDATE categories SET category_name = TRIM(REGEXP_REPLACE(category_name, '\s+', ' ', 'g'))
+		WHERE category_name <> TRIM(REGEXP_REPLACE(category_name, '\s+', ' ', 'g'))`).Error
+	if err != nil {
+		return err
+	}
+
+	var renamed []struct {
+		CategoryID   string
+		CategoryName string
+	}
+	err = tx.Raw(`WITH ranked AS (
+			SELECT category_id, ROW_NUMBER() OVER (
+				PARTITION BY COALESCE(parent_id, '00000000-0000-0000-0000-000000000000'::uuid), LOWER(category_name)
+				ORDER BY created_at, category_id
+			) AS n
+			FROM categories
+			WHERE deleted_at IS NULL
+		)
+		UPDATE categories SET category_name = categories.category_name || ' (' || ranked.n || ')'
+		FROM ranked
+		WHERE ranked.category_id = categories.category_id AND ranked.n > 1
+		RETURNING categories.category_id, categories.category_name`).Scan(&renamed).Error
+	if err != nil {
+		return err
+	}
+	for _, category := range renamed {
+		log.Warn("Database: renamed duplicate category", category.CategoryID, category.CategoryName)
+	}
+
+	return tx.Exec(`CREATE UNIQUE INDEX IF NOT EXISTS ` + repository.CategoryNameIndex + ` ON categories
+		(COALESCE(parent_id, '00000000-0000-0000-0000-000000000000'::uuid), LOWER(category_name))
+		WHERE deleted_at IS NULL`).Error
 }
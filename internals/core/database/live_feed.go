@@ -0,0 +1,80 @@
+package database
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/AthulKrishna2501/zyra-admin-service/internals/core/events"
+	"github.com/AthulKrishna2501/zyra-admin-service/internals/logger"
+	"gorm.io/gorm"
+)
+
+// liveFeedTables lists the inserts that are pushed to the live feed: the
+// event type, the column that identifies the row and the columns sent with
+// the event. Only listed columns leave the database, so a column added to
+// one of these tables later is not published until it is listed here.
+var liveFeedTables = []struct {
+	table     string
+	eventType string
+	idColumn  string
+	columns   []string
+}{
+	{"bookings", events.TypeBookingCreated, "booking_id",
+		[]string{"booking_id", "client_id", "vendor_id", "service", "date", "status", "price", "created_at"}},
+	{"category_requests", events.TypeCategoryRequestCreated, "request_id",
+		[]string{"request_id", "vendor_id", "category_id", "status", "created_at"}},
+	{"fund_releases", events.TypeFundReleaseRequested, "request_id",
+		[]string{"request_id", "event_id", "event_name", "amount", "tickets", "status", "created_at"}},
+	{"admin_wallet_transactions", events.TypeWalletTransaction, "transaction_id",
+		[]string{"transaction_id", "date", "type", "amount", "status"}},
+}
+
+// The notify function sends the listed columns of the new row with the
+// event; the trigger arguments are the event type, the ID column and then
+// the columns to send. Notification payloads are capped just under 8000
+// bytes, so a larger row is sent as its ID alone. NOTIFY is delivered on
+// commit, so rolled back inserts are never seen.
+const liveFeedFunction = `CREATE OR REPLACE FUNCTION admin_live_feed_notify() RETURNS trigger AS $$
+	DECLARE
+		row_data jsonb := to_jsonb(NEW);
+		data jsonb;
+		payload text;
+	BEGIN
+		SELECT jsonb_object_agg(key, value) INTO data
+		FROM jsonb_each(row_data)
+		WHERE key = ANY (TG_ARGV[2:]);
+
+		payload := json_build_object('type', TG_ARGV[0], 'id', row_data ->> TG_ARGV[1], 'occurred_at', NOW(), 'data', data)::text;
+		IF octet_length(payload) > 7900 THEN
+			payload := json_build_object('type', TG_ARGV[0], 'id', row_data ->> TG_ARGV[1], 'occurred_at', NOW())::text;
+		END IF;
+		PERFORM pg_notify('%s', payload);
+		RETURN NULL;
+	END;
+	$$ LANGUAGE plpgsql`
+
+// migrateLiveFeedTriggers installs the triggers that notify the live feed
+// of new rows.
+func migrateLiveFeedTriggers(tx *gorm.DB, log logger.Logger) error {
+	if err := tx.Exec(fmt.Sprintf(liveFeedFunction, events.Channel)).Error; err != nil {
+		return err
+	}
+
+	for _, t := range liveFeedTables {
+		trigger := "admin_live_feed_" + t.table
+		if err := tx.Exec(fmt.Sprintf("DROP TRIGGER IF EXISTS %s ON %s", trigger, t.table)).Error; err != nil {
+			return err
+		}
+
+		args := append([]string{t.eventType, t.idColumn}, t.columns...)
+		err := tx.Exec(fmt.Sprintf(
+			"CREATE TRIGGER %s AFTER INSERT ON %s FOR EACH ROW EXECUTE FUNCTION admin_live_feed_notify('%s')",
+			trigger, t.table, strings.Join(args, "', '"),
+		)).Error
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,73 @@
+package database
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/AthulKrishna2501/zyra-admin-service/internals/logger"
+	"gorm.io/gorm"
+)
+
+// SchemaMigration records a versioned migration that has been applied.
+type SchemaMigration struct {
+	Version   int       `gorm:"primaryKey;autoIncrement:false"`
+	Name      string    `gorm:"type:varchar(255);not null"`
+	AppliedAt time.Time `gorm:"autoCreateTime"`
+}
+
+// A migration is a schema or data change that AutoMigrate cannot express.
+// Each one runs once, in its own transaction, and is recorded in
+// schema_migrations. Append new migrations with the next version; never
+// edit or reorder one that has shipped.
+type migration struct {
+	version int
+	name    string
+	up      func(tx *gorm.DB, log logger.Logger) error
+}
+
+var migrations = []migration{
+	{1, "category_name_index", migrateCategoryNameIndex},
+	{2, "dashboard_counter_deltas", migrateDashboardCounters},
+	{3, "booking_stage_trigger", migrateBookingStageTrigger},
+	{4, "live_feed_triggers", migrateLiveFeedTriggers},
+	{5, "booking_payment_status", migrateBookingPaymentStatus},
+	{6, "dashboard_revenue_all_bookings", migrateDashboardRevenue},
+}
+
+// migrationLockKey is the advisory lock that keeps two replicas starting at
+// once from applying the same migration twice.
+const migrationLockKey = 727165001
+
+func runMigrations(db *gorm.DB, log logger.Logger) error {
+	if err := db.AutoMigrate(&SchemaMigration{}); err != nil {
+		return err
+	}
+
+	for _, m := range migrations {
+		err := db.Transaction(func(tx *gorm.DB) error {
+			if err := tx.Exec("SELECT pg_advisory_xact_lock(?)", migrationLockKey).Error; err != nil {
+				return err
+			}
+
+			var applied int64
+			if err := tx.Model(&SchemaMigration{}).Where("version = ?", m.version).Count(&applied).Error; err != nil {
+				return err
+			}
+			if applied > 0 {
+				return nil
+			}
+
+			if err := m.up(tx, log); err != nil {
+				return err
+			}
+
+			log.Info("Database: applied migration", m.version, m.name)
+			return tx.Create(&SchemaMigration{Version: m.version, Name: m.name}).Error
+		})
+		if err != nil {
+			return fmt.Errorf("migration %d %s: %w", m.version, m.name, err)
+		}
+	}
+
+	return nil
+}
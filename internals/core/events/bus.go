@@ -0,0 +1,103 @@
+package events
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Types of events on the live feed.
+const (
+	TypeBookingCreated         = "booking_created"
+	TypeCategoryRequestCreated = "category_request_created"
+	TypeFundReleaseRequested   = "fund_release_requested"
+	TypeWalletTransaction      = "wallet_transaction"
+)
+
+var knownTypes = map[string]bool{
+	TypeBookingCreated:         true,
+	TypeCategoryRequestCreated: true,
+	TypeFundReleaseRequested:   true,
+	TypeWalletTransaction:      true,
+}
+
+// Event is one change pushed to live feed subscribers. Data holds the
+// published columns of the new row as JSON; it is left out when the row is
+// too large for a notification.
+type Event struct {
+	Type       string          `json:"type"`
+	ID         string          `json:"id"`
+	OccurredAt time.Time       `json:"occurred_at"`
+	Data       json.RawMessage `json:"data,omitempty"`
+}
+
+// Filter selects event types. An empty filter matches every event.
+type Filter map[string]bool
+
+// ParseFilter builds a filter from a list of event types, rejecting types
+// the feed does not publish.
+func ParseFilter(types []string) (Filter, error) {
+	filter := Filter{}
+	for _, t := range types {
+		t = strings.ToLower(strings.TrimSpace(t))
+		if t == "" {
+			continue
+		}
+		if !knownTypes[t] {
+			return nil, fmt.Errorf("unknown event type %q", t)
+		}
+		filter[t] = true
+	}
+
+	return filter, nil
+}
+
+func (f Filter) Match(e Event) bool {
+	return len(f) == 0 || f[e.Type]
+}
+
+// Bus fans events out to in-process subscribers. Publishing never blocks:
+// a subscriber that falls more than its buffer behind misses events rather
+// than holding up everyone else.
+type Bus struct {
+	mu          sync.RWMutex
+	subscribers map[chan Event]struct{}
+}
+
+func NewBus() *Bus {
+	return &Bus{subscribers: map[chan Event]struct{}{}}
+}
+
+// Subscribe returns a channel of events published from now on and a
+// function that ends the subscription and closes the channel.
+func (b *Bus) Subscribe(buffer int) (<-chan Event, func()) {
+	ch := make(chan Event, buffer)
+
+	b.mu.Lock()
+	b.subscribers[ch] = struct{}{}
+	b.mu.Unlock()
+
+	var once sync.Once
+	return ch, func() {
+		once.Do(func() {
+			b.mu.Lock()
+			delete(b.subscribers, ch)
+			b.mu.Unlock()
+			close(ch)
+		})
+	}
+}
+
+func (b *Bus) Publish(e Event) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	for ch := range b.subscribers {
+		select {
+		case ch <- e:
+		default:
+		}
+	}
+}
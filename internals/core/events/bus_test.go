@@ -0,0 +1,87 @@
+package events
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseFilter(t *testing.T) {
+	tests := []struct {
+		name    string
+		types   []string
+		want    Filter
+		wantErr bool
+	}{
+		{"no types", nil, Filter{}, false},
+		{"blank types", []string{"", "  "}, Filter{}, false},
+		{"normalized", []string{" Booking_Created ", TypeWalletTransaction},
+			Filter{TypeBookingCreated: true, TypeWalletTransaction: true}, false},
+		{"unknown type", []string{TypeBookingCreated, "user_deleted"}, nil, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseFilter(tt.types)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseFilter() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseFilter() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFilterMatch(t *testing.T) {
+	tests := []struct {
+		name   string
+		filter Filter
+		event  Event
+		want   bool
+	}{
+		{"empty filter matches all", Filter{}, Event{Type: TypeFundReleaseRequested}, true},
+		{"listed type", Filter{TypeBookingCreated: true}, Event{Type: TypeBookingCreated}, true},
+		{"other type", Filter{TypeBookingCreated: true}, Event{Type: TypeWalletTransaction}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.filter.Match(tt.event); got != tt.want {
+				t.Errorf("Match() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBusDropsEventsForSlowSubscribers(t *testing.T) {
+	bus := NewBus()
+	slow, unsubscribeSlow := bus.Subscribe(1)
+	fast, unsubscribeFast := bus.Subscribe(3)
+	defer unsubscribeFast()
+
+	for _, id := range []string{"1", "2", "3"} {
+		bus.Publish(Event{Type: TypeBookingCreated, ID: id})
+	}
+
+	if got := (<-slow).ID; got != "1" {
+		t.Errorf("slow subscriber got %s, want 1", got)
+	}
+	if len(slow) != 0 {
+		t.Errorf("slow subscriber has %d buffered events, want 0", len(slow))
+	}
+	for _, want := range []string{"1", "2", "3"} {
+		if got := (<-fast).ID; got != want {
+			t.Errorf("fast subscriber got %s, want %s", got, want)
+		}
+	}
+
+	unsubscribeSlow()
+	unsubscribeSlow()
+	if _, ok := <-slow; ok {
+		t.Error("channel still open after unsubscribe")
+	}
+	bus.Publish(Event{Type: TypeBookingCreated, ID: "4"})
+	if got := (<-fast).ID; got != "4" {
+		t.Errorf("fast subscriber got %s after unsubscribe, want 4", got)
+	}
+}
@@ -0,0 +1,73 @@
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/AthulKrishna2501/zyra-admin-service/internals/logger"
+	"github.com/jackc/pgx/v5"
+)
+
+// Channel is the Postgres notification channel the live feed triggers
+// notify on.
+const Channel = "admin_live_feed"
+
+const (
+	minReconnectDelay = time.Second
+	maxReconnectDelay = 30 * time.Second
+)
+
+// Listen publishes the notifications on Channel to the bus until ctx is
+// cancelled. It holds its own connection, outside the GORM pool, as LISTEN
+// is tied to a session. Lost connections are retried with backoff; events
+// notified while disconnected are missed.
+func Listen(ctx context.Context, dsn string, bus *Bus, log logger.Logger) {
+	delay := minReconnectDelay
+
+	for {
+		err := listen(ctx, dsn, bus, log, func() { delay = minReconnectDelay })
+		if ctx.Err() != nil {
+			return
+		}
+
+		log.Error("Admin Service: live feed listener disconnected", err)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(delay):
+		}
+		delay = min(delay*2, maxReconnectDelay)
+	}
+}
+
+func listen(ctx context.Context, dsn string, bus *Bus, log logger.Logger, connected func()) error {
+	conn, err := pgx.Connect(ctx, dsn)
+	if err != nil {
+		return err
+	}
+	defer conn.Close(context.Background())
+
+	if _, err := conn.Exec(ctx, "LISTEN "+Channel); err != nil {
+		return err
+	}
+
+	connected()
+	log.Info("Admin Service: live feed listening", Channel)
+
+	for {
+		notification, err := conn.WaitForNotification(ctx)
+		if err != nil {
+			return err
+		}
+
+		var event Event
+		if err := json.Unmarshal([]byte(notification.Payload), &event); err != nil {
+			log.Warn("Admin Service: dropped malformed live feed notification", err)
+			continue
+		}
+
+		bus.Publish(event)
+	}
+}
@@ -7,6 +7,31 @@ import (
 	"github.com/google/uuid"
 )
 
+const (
+	RoleClient = "client"
+	RoleVendor = "vendor"
+	RoleAdmin  = "admin"
+
+	// BookingStatusPending is a booking the client has requested but not
+	// paid for yet.
+	BookingStatusPending   = "pending"
+	BookingStatusCancelled = "cancelled"
+	BookingStatusCompleted = "completed"
+	BookingStatusDisputed  = "disputed"
+
+	// Payment states of a booking. Only paid bookings are refunded when
+	// they are cancelled.
+	PaymentStatusUnpaid   = "unpaid"
+	PaymentStatusPaid     = "paid"
+	PaymentStatusRefunded = "refunded"
+
+	FundReleaseStatusPending = "pending"
+	FundReleaseStatusOnHold  = "on_hold"
+
+	TransactionTypeFundRelease   = "Fund Release"
+	TransactionTypeBookingRefund = "Booking Refund"
+)
+
 type AdminWallet struct {
 	Email            string    `json:"email"`
 	Balance          float64   `gorm:"default:0" json:"balance"`
@@ -24,6 +49,33 @@ type AdminWalletTransaction struct {
 	Status        string `gorm:"type:varchar(255)"`
 }
 
+// Names of the rows in dashboard_counters.
+const (
+	CounterTotalVendors  = "total_vendors"
+	CounterTotalClients  = "total_clients"
+	CounterTotalBookings = "total_bookings"
+	CounterTotalRevenue  = "total_revenue"
+)
+
+// DashboardCounter is one running total behind the admin dashboard. A
+// total is its Value plus the sum of its pending DashboardCounterDelta rows,
+// so the dashboard does not have to scan users and bookings.
+type DashboardCounter struct {
+	Name      string    `gorm:"type:varchar(50);primaryKey"`
+	Value     int64     `gorm:"not null;default:0"`
+	UpdatedAt time.Time `gorm:"autoUpdateTime"`
+}
+
+// DashboardCounterDelta is one change to a counter. Triggers on users and
+// bookings append deltas instead of updating the counter rows, so writers
+// never contend on them; compaction folds the deltas into the counters.
+type DashboardCounterDelta struct {
+	ID        int64     `gorm:"primaryKey;autoIncrement"`
+	Name      string    `gorm:"type:varchar(50);not null"`
+	Delta     int64     `gorm:"not null"`
+	CreatedAt time.Time `gorm:"autoCreateTime"`
+}
+
 type DashboardStats struct {
 	TotalVendors  int32
 	TotalClients  int32
@@ -31,6 +83,24 @@ type DashboardStats struct {
 	TotalRevenue  int64
 }
 
+// DashboardFilter narrows the dashboard to a slice of bookings. Zero values
+// are not filtered on. CategoryName matches bookings by service name against
+// the category and its subcategories, as bookings do not carry a category
+// ID; Service narrows that to one service.
+type DashboardFilter struct {
+	From         time.Time
+	To           time.Time
+	CategoryName string
+	Service      string
+	Statuses     []string
+}
+
+// HasSegment reports whether the filter selects a subset of bookings by
+// something other than date.
+func (f DashboardFilter) HasSegment() bool {
+	return f.CategoryName != "" || f.Service != "" || len(f.Statuses) > 0
+}
+
 type Booking struct {
 	ID               uuid.UUID          `gorm:"type:uuid;primaryKey;default:gen_random_uuid()"`
 	BookingID        uuid.UUID          `gorm:"type:uuid;default:gen_random_uuid()"`
@@ -42,11 +112,22 @@ type Booking struct {
 	Date             time.Time          `gorm:"type:date;not null"`
 	Status           string             `gorm:"type:varchar(50);not null"`
 	Price            int                `gorm:"not null"`
+	PaymentStatus    string             `gorm:"type:varchar(20);not null;default:'unpaid'"`
 	IsVendorApproved bool
 	IsClientApproved bool
 	IsFundReleased   bool
 	CreatedAt        time.Time `gorm:"autoCreateTime"`
 	UpdatedAt        time.Time `gorm:"autoUpdateTime"`
+
+	// Set by the booking stage trigger when the matching flag turns true,
+	// whichever service writes the booking.
+	VendorApprovedAt *time.Time
+	ClientApprovedAt *time.Time
+	FundReleasedAt   *time.Time
+
+	// Refunded is set on bookings returned by a cancellation when the
+	// client was refunded. It is not stored.
+	Refunded bool `gorm:"-"`
 }
 
 type UserInfo struct {
@@ -73,3 +154,60 @@ type EventDetails struct {
 	EventID string  `json:"event_id"`
 	Amount  float64 `json:"amount"`
 }
+
+type UserFilter struct {
+	Role          string
+	Email         string
+	IsBlocked     *bool
+	CreatedAfter  time.Time
+	CreatedBefore time.Time
+}
+
+// BlockUpdate is one user touched by a bulk block or unblock, with the
+// is_blocked value the user had before, so a failed update can be undone.
+type BlockUpdate struct {
+	UserID     string
+	WasBlocked bool
+}
+
+type UserActionResult struct {
+	UserID  string
+	Success bool
+	Message string
+}
+
+type HiddenVendorCategory struct {
+	VendorID   uuid.UUID `gorm:"type:uuid;primaryKey"`
+	CategoryID uuid.UUID `gorm:"type:uuid;primaryKey"`
+	Reason     string    `gorm:"type:varchar(255)"`
+	CreatedAt  time.Time `gorm:"autoCreateTime"`
+}
+
+type PayoutHold struct {
+	HoldID     uuid.UUID `gorm:"type:uuid;primaryKey;default:gen_random_uuid()"`
+	UserID     uuid.UUID `gorm:"type:uuid;not null;index"`
+	Reason     string    `gorm:"type:varchar(255)"`
+	ReleasedAt *time.Time
+	CreatedAt  time.Time `gorm:"autoCreateTime"`
+}
+
+type CascadeStepResult struct {
+	Step     string
+	Success  bool
+	Affected int64
+	Message  string
+}
+
+type RoleChange struct {
+	ChangeID     uuid.UUID `gorm:"type:uuid;primaryKey;default:gen_random_uuid()"`
+	UserID       uuid.UUID `gorm:"type:uuid;not null;index"`
+	PreviousRole string    `gorm:"type:varchar(50);not null"`
+	NewRole      string    `gorm:"type:varchar(50);not null"`
+	Reason       string    `gorm:"type:text"`
+	ChangedBy    string    `gorm:"type:varchar(255);not null"`
+	CreatedAt    time.Time `gorm:"autoCreateTime"`
+
+	// Set by ChangeUserRole when vendor data was removed with the role.
+	RemovedCategories int64 `gorm:"-"`
+	DroppedRequests   int64 `gorm:"-"`
+}
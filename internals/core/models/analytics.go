@@ -0,0 +1,134 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	GranularityDay   = "day"
+	GranularityWeek  = "week"
+	GranularityMonth = "month"
+)
+
+const (
+	LeaderboardVendors = "vendors"
+	LeaderboardClients = "clients"
+
+	RankByRevenue        = "revenue"
+	RankByBookings       = "bookings"
+	RankByCompletionRate = "completion_rate"
+	RankBySpend          = "spend"
+)
+
+// WalletOutflowTypes are the admin wallet transaction types that take money
+// out of the admin wallet. Every other type is money coming in.
+var WalletOutflowTypes = []string{TransactionTypeFundRelease, TransactionTypeBookingRefund}
+
+// Period is a half-open time range [From, To).
+type Period struct {
+	From time.Time
+	To   time.Time
+}
+
+// CategoryUsage is how much a category is used over a period. Bookings are
+// attributed to a category when their service matches the category name,
+// ignoring case, as bookings do not carry a category ID.
+type CategoryUsage struct {
+	CategoryID      uuid.UUID
+	CategoryName    string
+	ParentID        *uuid.UUID
+	ApprovedVendors int64
+	PendingRequests int64
+	NewRequests     int64
+	Bookings        int64
+	Revenue         int64
+}
+
+// Previous returns the period of the same length that ends where p starts.
+func (p Period) Previous() Period {
+	return Period{From: p.From.Add(-p.To.Sub(p.From)), To: p.From}
+}
+
+// TimeSeriesPoint holds the metrics for one day, week or month starting at
+// Bucket.
+type TimeSeriesPoint struct {
+	Bucket        time.Time
+	Bookings      int64
+	Revenue       int64
+	NewClients    int64
+	NewVendors    int64
+	WalletInflow  float64
+	WalletOutflow float64
+}
+
+// LeaderboardFilter selects which leaderboard to build and how to rank it.
+// Users with fewer than MinBookings bookings in the period are left out.
+type LeaderboardFilter struct {
+	Board       string
+	RankBy      string
+	Period      Period
+	MinBookings int
+	Limit       int
+	Offset      int
+}
+
+// LeaderboardEntry is one vendor or client on a leaderboard. Amount is the
+// revenue earned by a vendor or the spend of a client, without cancelled
+// bookings.
+type LeaderboardEntry struct {
+	Rank              int64
+	UserID            uuid.UUID
+	FirstName         string
+	LastName          string
+	Bookings          int64
+	CompletedBookings int64
+	Amount            int64
+	CompletionRate    float64
+}
+
+// BookingFunnelStages counts bookings that reached each stage, in order:
+// created, vendor approved, client approved and funds released. A booking
+// counts for a stage only if it also reached every earlier one. The medians
+// are in seconds from the previous stage and are nil when no booking has
+// timestamps for both stages.
+type BookingFunnelStages struct {
+	Created                     int64
+	VendorApproved              int64
+	ClientApproved              int64
+	FundReleased                int64
+	MedianVendorApprovalSeconds *float64
+	MedianClientApprovalSeconds *float64
+	MedianFundReleaseSeconds    *float64
+}
+
+type BookingStatusCount struct {
+	Status   string
+	Bookings int64
+	Revenue  int64
+}
+
+type BookingApprovalState struct {
+	IsVendorApproved bool
+	IsClientApproved bool
+	IsFundReleased   bool
+	Bookings         int64
+}
+
+type BookingFunnel struct {
+	Stages         BookingFunnelStages
+	Statuses       []BookingStatusCount
+	ApprovalStates []BookingApprovalState
+}
+
+// ClientCohortCell is one cell of the client retention matrix: of the Size
+// clients who signed up in the Cohort month, ActiveClients made a booking
+// that was not cancelled MonthOffset months later. Month 0 is the sign-up
+// month itself.
+type ClientCohortCell struct {
+	Cohort        time.Time
+	Size          int64
+	MonthOffset   int
+	ActiveClients int64
+}
@@ -0,0 +1,101 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Where a flagged money movement comes from.
+const (
+	AnomalySourceAdminWallet  = "admin_wallet_transaction"
+	AnomalySourceFundRelease  = "fund_release"
+	AnomalySourceClientCredit = "client_wallet_credit"
+)
+
+const (
+	// AnomalyRuleAmountSpike flags a movement far above the usual amount
+	// for its source and type.
+	AnomalyRuleAmountSpike = "amount_spike"
+	// AnomalyRuleRepeatedPayout flags a host receiving many fund releases
+	// in a short window.
+	AnomalyRuleRepeatedPayout = "repeated_payout"
+	// AnomalyRuleVelocity flags many movements from one source, per user
+	// where there is one, in a short window.
+	AnomalyRuleVelocity = "velocity"
+)
+
+const (
+	AnomalyAlertOpen         = "open"
+	AnomalyAlertAcknowledged = "acknowledged"
+)
+
+// AnomalySettings holds the thresholds of the anomaly rules. There is a
+// single row, with ID 1, so they can be tuned at runtime.
+type AnomalySettings struct {
+	ID      uint `gorm:"primaryKey"`
+	Enabled bool `gorm:"not null;default:false"`
+
+	// A movement is a spike when it is SpikeZScore standard deviations
+	// above the mean of the previous SpikeBaselineDays, provided the
+	// baseline has at least SpikeMinSamples movements.
+	SpikeZScore       float64 `gorm:"not null;default:3"`
+	SpikeBaselineDays int     `gorm:"not null;default:30"`
+	SpikeMinSamples   int     `gorm:"not null;default:20"`
+
+	RepeatedPayoutCount       int `gorm:"not null;default:3"`
+	RepeatedPayoutWindowHours int `gorm:"not null;default:24"`
+
+	VelocityMaxCount      int `gorm:"not null;default:10"`
+	VelocityWindowMinutes int `gorm:"not null;default:60"`
+
+	UpdatedBy string    `gorm:"type:varchar(255)"`
+	UpdatedAt time.Time `gorm:"autoUpdateTime"`
+}
+
+// AnomalyAlert is one flagged movement; for the window rules, the movement
+// that completed the burst. SubjectKey is that movement's ID within the
+// source, so a rescan does not raise the same alert twice.
+type AnomalyAlert struct {
+	AlertID        uuid.UUID  `gorm:"type:uuid;primaryKey;default:gen_random_uuid()"`
+	Rule           string     `gorm:"type:varchar(50);not null;uniqueIndex:idx_anomaly_alerts_subject"`
+	Source         string     `gorm:"type:varchar(50);not null;uniqueIndex:idx_anomaly_alerts_subject"`
+	SubjectKey     string     `gorm:"type:varchar(255);not null;uniqueIndex:idx_anomaly_alerts_subject"`
+	UserID         *uuid.UUID `gorm:"type:uuid;index"`
+	Amount         float64
+	Score          float64
+	Details        string    `gorm:"type:text;not null"`
+	OccurredAt     time.Time `gorm:"not null"`
+	Status         string    `gorm:"type:varchar(50);not null;default:'open';index"`
+	AcknowledgedBy string    `gorm:"type:varchar(255)"`
+	AcknowledgedAt *time.Time
+	Note           string    `gorm:"type:text"`
+	CreatedAt      time.Time `gorm:"autoCreateTime"`
+}
+
+// AnomalyCandidate is a movement that broke a rule, or for the window rules
+// the movement that brought its window to the threshold. Mean, StdDev and Samples describe the baseline of a spike;
+// Count is the number of movements in the window for the window rules.
+type AnomalyCandidate struct {
+	Source     string
+	SubjectKey string
+	UserID     *uuid.UUID
+	Amount     float64
+	OccurredAt time.Time
+	Score      float64
+	Mean       float64
+	StdDev     float64
+	Samples    int64
+	Count      int64
+}
+
+// AnomalyAlertFilter narrows ListAnomalyAlerts. Empty fields are not
+// filtered on.
+type AnomalyAlertFilter struct {
+	Status string
+	Rule   string
+	Source string
+	UserID string
+	Limit  int
+	Offset int
+}
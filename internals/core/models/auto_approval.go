@@ -0,0 +1,46 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// AutoApprovalActor is recorded as DecidedBy on automatic decisions.
+	AutoApprovalActor = "auto-approval"
+
+	AutoApprovalOutcomeApproved     = "approved"
+	AutoApprovalOutcomeManualReview = "manual_review"
+)
+
+// AutoApprovalSettings holds the rules a new category request has to pass
+// to be approved without an admin. There is a single row, with ID 1, so the
+// rules can be changed at runtime. A vendor always has to be verified, as
+// for a manual approval.
+type AutoApprovalSettings struct {
+	ID                   uint      `gorm:"primaryKey"`
+	Enabled              bool      `gorm:"not null;default:false"`
+	MinAccountAgeDays    int       `gorm:"not null;default:0"`
+	MinCompletedBookings int       `gorm:"not null;default:0"`
+	MaxDisputes          int       `gorm:"not null;default:0"`
+	UpdatedBy            string    `gorm:"type:varchar(255)"`
+	UpdatedAt            time.Time `gorm:"autoUpdateTime"`
+}
+
+// AutoApprovalEvaluation records what the rules decided for a request and
+// why, whether it was approved or left for an admin.
+type AutoApprovalEvaluation struct {
+	EvaluationID uuid.UUID `gorm:"type:uuid;primaryKey;default:gen_random_uuid()"`
+	RequestID    uuid.UUID `gorm:"type:uuid;not null;index"`
+	Outcome      string    `gorm:"type:varchar(50);not null"`
+	Explanation  string    `gorm:"type:text;not null"`
+	CreatedAt    time.Time `gorm:"autoCreateTime"`
+}
+
+// VendorStanding is what the auto-approval rules know about a vendor.
+type VendorStanding struct {
+	AccountCreatedAt  time.Time
+	CompletedBookings int64
+	Disputes          int64
+}
@@ -0,0 +1,65 @@
+package models
+
+import (
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Category maps the shared categories table. The admin service owns the
+// category lifecycle, so it carries the columns the vendor service model
+// does not know about, such as the soft delete marker.
+type Category struct {
+	CategoryID     uuid.UUID      `gorm:"type:uuid;primaryKey;default:gen_random_uuid()"`
+	CategoryName   string         `gorm:"type:varchar(255);not null"`
+	ParentID       *uuid.UUID     `gorm:"type:uuid;index"`
+	Description    string         `gorm:"type:text"`
+	IconURL        string         `gorm:"type:text"`
+	DisplayOrder   int            `gorm:"not null;default:0"`
+	IsActive       bool           `gorm:"not null;default:true"`
+	Slug           string         `gorm:"type:varchar(255);not null;default:'';uniqueIndex:idx_categories_slug,where:slug <> '' AND deleted_at IS NULL"`
+	CommissionRate float64        `gorm:"type:numeric(5,2);not null;default:0"`
+	CreatedAt      time.Time      `gorm:"autoCreateTime"`
+	UpdatedAt      time.Time      `gorm:"autoUpdateTime"`
+	DeletedAt      gorm.DeletedAt `gorm:"index"`
+}
+
+// NormalizeCategoryName trims a category name and collapses inner runs of
+// whitespace, so " Wedding  Photography " is stored as "Wedding Photography".
+// Names are compared case-insensitively on top of this.
+func NormalizeCategoryName(name string) string {
+	return strings.Join(strings.Fields(name), " ")
+}
+
+type CategoryMergeResult struct {
+	MovedVendors  int64
+	MovedRequests int64
+}
+
+// CategoryDetailsUpdate carries the metadata fields to change on a category;
+// nil fields are left untouched.
+type CategoryDetailsUpdate struct {
+	Description    *string
+	IconURL        *string
+	DisplayOrder   *int
+	IsActive       *bool
+	Slug           *string
+	CommissionRate *float64
+}
+
+// CategoryImportRow is one row of a category import file. Parents are
+// referenced by slug so a file can create a whole subtree at once.
+type CategoryImportRow struct {
+	Row            int      `json:"-"`
+	Name           string   `json:"name"`
+	Slug           string   `json:"slug"`
+	ParentSlug     string   `json:"parent_slug"`
+	Description    string   `json:"description"`
+	IconURL        string   `json:"icon_url"`
+	DisplayOrder   int      `json:"display_order"`
+	IsActive       *bool    `json:"is_active"`
+	CommissionRate float64  `json:"commission_rate"`
+	Errors         []string `json:"-"`
+}
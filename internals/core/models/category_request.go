@@ -0,0 +1,101 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	CategoryRequestPending  = "pending"
+	CategoryRequestApproved = "approved"
+	CategoryRequestRejected = "rejected"
+
+	RejectionIncompleteProfile     = "incomplete_profile"
+	RejectionInsufficientPortfolio = "insufficient_portfolio"
+	RejectionCategoryMismatch      = "category_mismatch"
+	RejectionDuplicateRequest      = "duplicate_request"
+	RejectionPolicyViolation       = "policy_violation"
+	RejectionOther                 = "other"
+
+	// UnknownDecider is recorded as DecidedBy when a legacy caller does not
+	// say who decided.
+	UnknownDecider = "unknown"
+
+	SLABucketOnTrack = "on_track"
+	SLABucketDueSoon = "due_soon"
+	SLABucketOverdue = "overdue"
+)
+
+// RejectionReasons lists the structured reasons an admin can give when
+// rejecting a category request, with the label shown to the vendor.
+var RejectionReasons = []struct {
+	Code  string
+	Label string
+}{
+	{RejectionIncompleteProfile, "Vendor profile is incomplete"},
+	{RejectionInsufficientPortfolio, "Not enough work samples or experience for this category"},
+	{RejectionCategoryMismatch, "Services offered do not match the category"},
+	{RejectionDuplicateRequest, "A request for this category already exists"},
+	{RejectionPolicyViolation, "Request violates marketplace policy"},
+	{RejectionOther, "Other, see notes"},
+}
+
+// CategoryDecision is what an admin (or an automatic rule) decides on a
+// category request.
+type CategoryDecision struct {
+	Status     string
+	ReasonCode string
+	Notes      string
+	DecidedBy  string
+}
+
+// CategoryRequest maps the category_requests table written by the vendor
+// service. The admin service adds its own ID and status columns so each
+// request is decided on its own instead of per vendor.
+type CategoryRequest struct {
+	RequestID  uuid.UUID `gorm:"type:uuid;not null;default:gen_random_uuid();uniqueIndex"`
+	VendorID   uuid.UUID `gorm:"type:uuid;not null;index"`
+	CategoryID uuid.UUID `gorm:"type:uuid;not null;index"`
+	Status     string    `gorm:"type:varchar(50);not null;default:'pending';index"`
+	DecidedBy  string    `gorm:"type:varchar(255)"`
+	DecidedAt  *time.Time
+	ReasonCode string `gorm:"type:varchar(50)"`
+	Notes      string `gorm:"type:text"`
+	AssignedTo string `gorm:"type:varchar(255);index"`
+	AssignedAt *time.Time
+	// AutoEvaluatedAt is set once the auto-approval rules have looked at
+	// the request, whatever the outcome.
+	AutoEvaluatedAt *time.Time `gorm:"index"`
+	CreatedAt       time.Time  `gorm:"autoCreateTime"`
+	CategoryName    string     `gorm:"->;-:migration"`
+	VendorName      string     `gorm:"->;-:migration"`
+}
+
+// CategoryRequestFilter narrows the category request queue. Empty fields
+// are not filtered on.
+type CategoryRequestFilter struct {
+	Status      string
+	CategoryID  string
+	VendorID    string
+	AssignedTo  string
+	Unassigned  bool
+	NewestFirst bool
+	Limit       int
+	Offset      int
+}
+
+type CategoryRequestAgeBucket struct {
+	Bucket string
+	Count  int64
+}
+
+type CategoryRequestDecision struct {
+	DecisionID uuid.UUID `gorm:"type:uuid;primaryKey;default:gen_random_uuid()"`
+	RequestID  uuid.UUID `gorm:"type:uuid;not null;index"`
+	Status     string    `gorm:"type:varchar(50);not null"`
+	ReasonCode string    `gorm:"type:varchar(50)"`
+	Notes      string    `gorm:"type:text"`
+	DecidedBy  string    `gorm:"type:varchar(255)"`
+	CreatedAt  time.Time `gorm:"autoCreateTime"`
+}
@@ -0,0 +1,26 @@
+package models
+
+import "testing"
+
+func TestNormalizeCategoryName(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		want string
+	}{
+		{"already normal", "Wedding Photography", "Wedding Photography"},
+		{"outer spaces", "  Catering ", "Catering"},
+		{"inner runs", "Wedding   Photography", "Wedding Photography"},
+		{"tabs and newlines", "Live\t\nMusic", "Live Music"},
+		{"case kept", "DJ  services", "DJ services"},
+		{"blank", " \t ", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := NormalizeCategoryName(tt.in); got != tt.want {
+				t.Errorf("NormalizeCategoryName(%q) = %q, want %q", tt.in, got, tt.want)
+			}
+		})
+	}
+}
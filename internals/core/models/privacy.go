@@ -0,0 +1,33 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// UserDataExport is the archive handed to a user who asks for a copy of
+// their data. Rows are kept as generic maps so columns owned by the other
+// services are exported without this service having to mirror them.
+type UserDataExport struct {
+	GeneratedAt      time.Time                `json:"generated_at"`
+	UserID           string                   `json:"user_id"`
+	Profile          map[string]interface{}   `json:"profile"`
+	Details          map[string]interface{}   `json:"details"`
+	Bookings         []map[string]interface{} `json:"bookings"`
+	Transactions     []map[string]interface{} `json:"transactions"`
+	Wallet           map[string]interface{}   `json:"wallet,omitempty"`
+	Events           []map[string]interface{} `json:"events"`
+	CategoryRequests []map[string]interface{} `json:"category_requests"`
+	VendorCategories []map[string]interface{} `json:"vendor_categories"`
+	Verifications    []map[string]interface{} `json:"verifications"`
+	Documents        []map[string]interface{} `json:"verification_documents"`
+}
+
+type DataErasure struct {
+	ErasureID   uuid.UUID `gorm:"type:uuid;primaryKey;default:gen_random_uuid()"`
+	UserID      uuid.UUID `gorm:"type:uuid;not null;index"`
+	RequestedBy string    `gorm:"type:varchar(255);not null"`
+	Reason      string    `gorm:"type:text"`
+	CreatedAt   time.Time `gorm:"autoCreateTime"`
+}
@@ -0,0 +1,41 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	MembershipActive  = "active"
+	MembershipHidden  = "hidden"
+	MembershipRevoked = "revoked"
+
+	// What happens to the vendor's open bookings in a category when their
+	// membership is revoked.
+	RevokeBookingsKeep   = "keep"
+	RevokeBookingsCancel = "cancel"
+	RevokeBookingsRefuse = "refuse"
+)
+
+// CategoryRevocation is the audit row written when an admin removes a vendor
+// from a category.
+type CategoryRevocation struct {
+	RevocationID     uuid.UUID `gorm:"type:uuid;primaryKey;default:gen_random_uuid()"`
+	VendorID         uuid.UUID `gorm:"type:uuid;not null;index"`
+	CategoryID       uuid.UUID `gorm:"type:uuid;not null;index"`
+	Reason           string    `gorm:"type:text;not null"`
+	BookingPolicy    string    `gorm:"type:varchar(50);not null"`
+	BookingsAffected int64     `gorm:"not null;default:0"`
+	RevokedBy        string    `gorm:"type:varchar(255)"`
+	CreatedAt        time.Time `gorm:"autoCreateTime"`
+}
+
+// VendorCategoryMembership is one category a vendor is, or was, listed in.
+type VendorCategoryMembership struct {
+	CategoryID   uuid.UUID
+	CategoryName string
+	Status       string
+	Reason       string
+	Since        *time.Time
+}
@@ -0,0 +1,61 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	VerificationStatusPending  = "pending"
+	VerificationStatusInReview = "in_review"
+	VerificationStatusApproved = "approved"
+	VerificationStatusRejected = "rejected"
+
+	DocumentKindIdentity = "identity"
+	DocumentKindBusiness = "business"
+)
+
+type VendorVerification struct {
+	VerificationID uuid.UUID `gorm:"type:uuid;primaryKey;default:gen_random_uuid()"`
+	VendorID       uuid.UUID `gorm:"type:uuid;not null;index"`
+	Status         string    `gorm:"type:varchar(50);not null;default:'pending'"`
+	Reason         string    `gorm:"type:text"`
+	ReviewedBy     string    `gorm:"type:varchar(255)"`
+	ReviewedAt     *time.Time
+	Documents      []VerificationDocument `gorm:"foreignKey:VerificationID;references:VerificationID"`
+	Checks         []VerificationCheck    `gorm:"foreignKey:VerificationID;references:VerificationID"`
+	CreatedAt      time.Time              `gorm:"autoCreateTime"`
+	UpdatedAt      time.Time              `gorm:"autoUpdateTime"`
+}
+
+type VerificationDocument struct {
+	DocumentID     uuid.UUID `gorm:"type:uuid;primaryKey;default:gen_random_uuid()"`
+	VerificationID uuid.UUID `gorm:"type:uuid;not null;index"`
+	Kind           string    `gorm:"type:varchar(50);not null"`
+	DocumentType   string    `gorm:"type:varchar(100);not null"`
+	DocumentNumber string    `gorm:"type:varchar(255)"`
+	FileURL        string    `gorm:"type:text;not null"`
+	CreatedAt      time.Time `gorm:"autoCreateTime"`
+}
+
+type VerificationCheck struct {
+	CheckID        uuid.UUID `gorm:"type:uuid;primaryKey;default:gen_random_uuid()"`
+	VerificationID uuid.UUID `gorm:"type:uuid;not null;index"`
+	CheckType      string    `gorm:"type:varchar(100);not null"`
+	Passed         bool
+	Notes          string    `gorm:"type:text"`
+	PerformedBy    string    `gorm:"type:varchar(255)"`
+	CreatedAt      time.Time `gorm:"autoCreateTime"`
+}
+
+type VerificationQueueItem struct {
+	VerificationID uuid.UUID
+	VendorID       uuid.UUID
+	VendorName     string
+	Email          string
+	Status         string
+	DocumentCount  int32
+	CheckCount     int32
+	CreatedAt      time.Time
+}
@@ -2,12 +2,11 @@ package repository
 
 import (
 	"context"
-	"errors"
 	"fmt"
 	"log"
+	"time"
 
 	adminModel "github.com/AthulKrishna2501/zyra-admin-service/internals/core/models"
-	auth "github.com/AthulKrishna2501/zyra-auth-service/internals/core/models"
 	clientModel "github.com/AthulKrishna2501/zyra-client-service/internals/core/models"
 	"github.com/AthulKrishna2501/zyra-vendor-service/internals/core/models"
 	"github.com/google/uuid"
@@ -19,15 +18,15 @@ type AdminStorage struct {
 }
 
 type AdminRepository interface {
-	UpdateCategoryRequestStatus(ctx context.Context, vendorID, categoryID, status string) error
-	UpdateRequestStatus(ctx context.Context, vendorID, status string) error
 	GetAllUsers(ctx context.Context) ([]adminModel.UserInfo, error)
-	ListCategories(ctx context.Context) ([]models.Category, error)
+	ListCategories(ctx context.Context, activeOnly bool) ([]adminModel.Category, error)
 	AddVendorCategory(ctx context.Context, VendorID, CategoryID string) error
-	GetRequests(ctx context.Context) ([]models.CategoryRequest, error)
-	CreateCategory(ctx context.Context, name string) error
-	DeleteRequest(ctx context.Context, vendorID string) error
+	GetRequests(ctx context.Context, filter adminModel.CategoryRequestFilter) ([]adminModel.CategoryRequest, int64, error)
+	CreateCategory(ctx context.Context, category *adminModel.Category) error
 	GetAdminDashboard(ctx context.Context) (*adminModel.DashboardStats, error)
+	CountDashboardStats(ctx context.Context) (*adminModel.DashboardStats, error)
+	ReconcileDashboardCounters(ctx context.Context) (before, after *adminModel.DashboardStats, err error)
+	CompactDashboardCounters(ctx context.Context) error
 	GetAdminWallet(ctx context.Context, email string) (*adminModel.AdminWallet, error)
 	GetAllBookings(ctx context.Context) ([]adminModel.Booking, error)
 	GetAllAdminTransactions(ctx context.Context) ([]adminModel.AdminWalletTransaction, error)
@@ -39,6 +38,66 @@ type AdminRepository interface {
 	CreditAmountToClientWallet(ctx context.Context, amount float64, userID string) error
 	DebitAmountFromAdminWallet(ctx context.Context, amount float64, adminEmail string) error
 	CreateAdminWalletTransaction(ctx context.Context, newAdminWalletTransaction *adminModel.AdminWalletTransaction) error
+	FindUserIDs(ctx context.Context, filter adminModel.UserFilter) ([]string, error)
+	SetUsersBlocked(ctx context.Context, userIDs []string, blocked bool) ([]adminModel.BlockUpdate, error)
+	GetUserRole(ctx context.Context, userID string) (string, error)
+	GetUserStatus(ctx context.Context, userID string) (string, error)
+	HideVendorCategories(ctx context.Context, vendorID, reason string) (int64, error)
+	RestoreVendorCategories(ctx context.Context, vendorID string) (int64, error)
+	CancelUpcomingVendorBookings(ctx context.Context, vendorID, adminEmail string) ([]adminModel.Booking, error)
+	HoldPayouts(ctx context.Context, userID, reason string) (int64, error)
+	ReleasePayoutHolds(ctx context.Context, userID string) (int64, error)
+	HasActivePayoutHold(ctx context.Context, userID string) (bool, error)
+	CreateVendorVerification(ctx context.Context, verification *adminModel.VendorVerification) error
+	GetVendorVerification(ctx context.Context, verificationID string) (*adminModel.VendorVerification, error)
+	GetLatestVendorVerification(ctx context.Context, vendorID string) (*adminModel.VendorVerification, error)
+	ListVerificationQueue(ctx context.Context, statuses []string, limit, offset int) ([]adminModel.VerificationQueueItem, int64, error)
+	CreateVerificationCheck(ctx context.Context, check *adminModel.VerificationCheck) error
+	DecideVendorVerification(ctx context.Context, verificationID, status, reason, reviewedBy string) error
+	ExportUserData(ctx context.Context, userID string) (*adminModel.UserDataExport, error)
+	EraseUserData(ctx context.Context, erasure *adminModel.DataErasure) error
+	CountVendorCategories(ctx context.Context, vendorID string) (int64, error)
+	CountOpenVendorBookings(ctx context.Context, vendorID string) (int64, error)
+	ChangeUserRole(ctx context.Context, change *adminModel.RoleChange, removeVendorData bool) error
+	ListRoleChanges(ctx context.Context, userID string, limit, offset int) ([]adminModel.RoleChange, int64, error)
+	GetCategory(ctx context.Context, categoryID string) (*adminModel.Category, error)
+	RenameCategory(ctx context.Context, categoryID, name string) error
+	DeleteCategory(ctx context.Context, categoryID string) error
+	RestoreCategory(ctx context.Context, categoryID string) error
+	MergeCategories(ctx context.Context, sourceID, targetID string) (*adminModel.CategoryMergeResult, error)
+	MoveCategory(ctx context.Context, categoryID string, parentID *uuid.UUID) error
+	SlugExists(ctx context.Context, slug string) (bool, error)
+	UpdateCategoryDetails(ctx context.Context, categoryID string, update adminModel.CategoryDetailsUpdate) error
+	CreateCategories(ctx context.Context, categories []*adminModel.Category) error
+	GetCategoryRequest(ctx context.Context, requestID string) (*adminModel.CategoryRequest, error)
+	FindPendingCategoryRequest(ctx context.Context, vendorID, categoryID string) (*adminModel.CategoryRequest, error)
+	DecideCategoryRequest(ctx context.Context, requestID string, decision adminModel.CategoryDecision) error
+	GetCategoryRequestDecisions(ctx context.Context, requestIDs []uuid.UUID) ([]adminModel.CategoryRequestDecision, error)
+	GetVendorCategoryRequests(ctx context.Context, vendorID, categoryID string) ([]adminModel.CategoryRequest, error)
+	CountCategoryRequestsByAge(ctx context.Context, filter adminModel.CategoryRequestFilter, dueSoon, overdue time.Duration) ([]adminModel.CategoryRequestAgeBucket, error)
+	AssignCategoryRequest(ctx context.Context, requestID, assignee string, reassign bool) (*adminModel.CategoryRequest, error)
+	GetCategoryUsage(ctx context.Context, period adminModel.Period) ([]adminModel.CategoryUsage, error)
+	GetTimeSeries(ctx context.Context, period adminModel.Period, granularity string) ([]adminModel.TimeSeriesPoint, error)
+	GetFilteredDashboard(ctx context.Context, filter adminModel.DashboardFilter) (*adminModel.DashboardStats, error)
+	GetLeaderboard(ctx context.Context, filter adminModel.LeaderboardFilter) ([]adminModel.LeaderboardEntry, int64, error)
+	GetBookingFunnel(ctx context.Context, filter adminModel.DashboardFilter) (*adminModel.BookingFunnel, error)
+	GetClientCohorts(ctx context.Context, period adminModel.Period, months int, now time.Time) ([]adminModel.ClientCohortCell, error)
+	GetAnomalySettings(ctx context.Context) (*adminModel.AnomalySettings, error)
+	SaveAnomalySettings(ctx context.Context, settings *adminModel.AnomalySettings) error
+	FindAmountSpikes(ctx context.Context, period adminModel.Period, settings *adminModel.AnomalySettings) ([]adminModel.AnomalyCandidate, error)
+	FindMovementBursts(ctx context.Context, period adminModel.Period, sources []string, window time.Duration, threshold int) ([]adminModel.AnomalyCandidate, error)
+	SaveAnomalyAlerts(ctx context.Context, alerts []adminModel.AnomalyAlert) ([]adminModel.AnomalyAlert, error)
+	ListAnomalyAlerts(ctx context.Context, filter adminModel.AnomalyAlertFilter) ([]adminModel.AnomalyAlert, int64, error)
+	AcknowledgeAnomalyAlert(ctx context.Context, alertID, adminEmail, note string) (*adminModel.AnomalyAlert, error)
+	RevokeVendorCategory(ctx context.Context, revocation *adminModel.CategoryRevocation, categoryName, adminEmail string) ([]adminModel.Booking, error)
+	GetVendorCategoryMemberships(ctx context.Context, vendorID string) ([]adminModel.VendorCategoryMembership, error)
+	GetAutoApprovalSettings(ctx context.Context) (*adminModel.AutoApprovalSettings, error)
+	SaveAutoApprovalSettings(ctx context.Context, settings *adminModel.AutoApprovalSettings) error
+	ListUnevaluatedCategoryRequests(ctx context.Context, limit int) ([]adminModel.CategoryRequest, error)
+	GetVendorStanding(ctx context.Context, vendorID string) (*adminModel.VendorStanding, error)
+	RecordAutoApprovalEvaluation(ctx context.Context, evaluation *adminModel.AutoApprovalEvaluation) error
+	AutoApproveCategoryRequest(ctx context.Context, decision adminModel.CategoryDecision, evaluation *adminModel.AutoApprovalEvaluation) error
+	ListAutoApprovalEvaluations(ctx context.Context, requestID string, limit, offset int) ([]adminModel.AutoApprovalEvaluation, int64, error)
 }
 
 func NewAdminRepository(db *gorm.DB) AdminRepository {
@@ -47,24 +106,6 @@ func NewAdminRepository(db *gorm.DB) AdminRepository {
 	}
 }
 
-func (r *AdminStorage) UpdateCategoryRequestStatus(ctx context.Context, vendorID, categoryID, status string) error {
-	err := r.DB.WithContext(ctx).Model(&auth.User{}).
-		Where("user_id = ?", vendorID).
-		Update("status", status)
-
-	if err != nil {
-		return errors.New("failed to updated user status")
-	}
-
-	err = r.DB.Where("vendor_id = ?", vendorID).Delete(&models.CategoryRequest{})
-
-	if err != nil {
-		return errors.New("failed to delete category request")
-	}
-
-	return nil
-}
-
 func (r *AdminStorage) GetAllUsers(ctx context.Context) ([]adminModel.UserInfo, error) {
 	var users []adminModel.UserInfo
 
@@ -88,20 +129,6 @@ func (r *AdminStorage) GetAllUsers(ctx context.Context) ([]adminModel.UserInfo,
 	return users, nil
 }
 
-func (r *AdminStorage) UpdateRequestStatus(ctx context.Context, vendorID, status string) error {
-	result := r.DB.WithContext(ctx).Model(&auth.User{}).Where("user_id = ?", vendorID).Update("status", status)
-
-	if result.Error != nil {
-		return result.Error
-	}
-
-	if result.RowsAffected == 0 {
-		return fmt.Errorf("no records updated, user_id %s not found", vendorID)
-	}
-
-	return nil
-}
-
 func (r *AdminStorage) AddVendorCategory(ctx context.Context, VendorID, CategoryID string) error {
 	vendorUUID, err := uuid.Parse(VendorID)
 	if err != nil {
@@ -126,60 +153,101 @@ func (r *AdminStorage) AddVendorCategory(ctx context.Context, VendorID, Category
 	return nil
 }
 
-func (r *AdminStorage) GetRequests(ctx context.Context) ([]models.CategoryRequest, error) {
-	var CatRequests []models.CategoryRequest
-	result := r.DB.WithContext(ctx).
+// GetRequests returns one page of the category request queue, oldest first
+// unless the filter asks otherwise, along with the total number of matches.
+func (r *AdminStorage) GetRequests(ctx context.Context, filter adminModel.CategoryRequestFilter) ([]adminModel.CategoryRequest, int64, error) {
+	var CatRequests []adminModel.CategoryRequest
+	var total int64
+
+	query := categoryRequestQuery(r.DB.WithContext(ctx), filter).Session(&gorm.Session{})
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	order := "category_requests.created_at ASC"
+	if filter.NewestFirst {
+		order = "category_requests.created_at DESC"
+	}
+
+	result := query.
 		Joins("JOIN categories c ON c.category_id = category_requests.category_id").
-		Joins("JOIN user_details u ON u.user_id = category_requests.vendor_id").
-		Select("category_requests.vendor_id, category_requests.category_id, category_requests.created_at,c.category_name as category_name, u.first_name as vendor_name").
+		Joins("LEFT JOIN user_details u ON u.user_id = category_requests.vendor_id").
+		Select("category_requests.request_id, category_requests.vendor_id, category_requests.category_id, category_requests.status, category_requests.decided_by, category_requests.decided_at, category_requests.reason_code, category_requests.notes, category_requests.assigned_to, category_requests.assigned_at, category_requests.created_at, c.category_name as category_name, u.first_name as vendor_name").
+		Order(order).
+		Limit(filter.Limit).
+		Offset(filter.Offset).
 		Find(&CatRequests)
 
 	if result.Error != nil {
-		return nil, result.Error
+		return nil, 0, result.Error
 	}
 
-	if result.RowsAffected == 0 {
-		return nil, errors.New("no requests in the queue")
-	}
-
-	return CatRequests, nil
+	return CatRequests, total, nil
 }
 
-func (r *AdminStorage) CreateCategory(ctx context.Context, name string) error {
-	var category models.Category
-	log.Print("Category to be added :", name)
-	err := r.DB.WithContext(ctx).Where("category_name = ?", name).First(&category).Error
+func (r *AdminStorage) CreateCategory(ctx context.Context, category *adminModel.Category) error {
+	log.Print("Category to be added :", category.CategoryName)
 
-	if errors.Is(err, gorm.ErrRecordNotFound) {
-		newCategory := &models.Category{
-			CategoryName: name,
+	err := r.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if category.ParentID != nil {
+			if err := requireActiveCategory(tx, category.ParentID.String()); err != nil {
+				return err
+			}
 		}
 
-		if err := r.DB.WithContext(ctx).Create(newCategory).Error; err != nil {
+		taken, err := siblingNameTaken(tx, category.CategoryName, category.ParentID, nil)
+		if err != nil {
 			return err
 		}
-		return nil
+		if taken {
+			return ErrCategoryExists
+		}
 
-	} else if err != nil {
-		return err
-	}
+		if category.Slug != "" {
+			taken, err := slugTaken(tx, category.Slug, nil)
+			if err != nil {
+				return err
+			}
+			if taken {
+				return ErrSlugExists
+			}
+		}
 
-	return fmt.Errorf("category name '%s' already exists", name)
+		if err := tx.Create(category).Error; err != nil {
+			return err
+		}
 
+		// is_active defaults to true in the database, so an inactive
+		// category has to be switched off after the insert.
+		if !category.IsActive {
+			return tx.Model(category).Update("is_active", false).Error
+		}
+
+		return nil
+	})
+
+	return categoryConstraintError(err)
 }
 
-func (r *AdminStorage) DeleteRequest(ctx context.Context, vendorID string) error {
-	if err := r.DB.WithContext(ctx).
-		Where("vendor_id = ?", vendorID).
-		Delete(&models.CategoryRequest{}).Error; err != nil {
-		return fmt.Errorf("failed to delete category request for vendor_id %s", vendorID)
+// GetAdminDashboard reads the all-time stats from the dashboard counters
+// and their pending deltas, which triggers keep up to date. It falls back
+// to counting the tables if the counters have not been set up.
+func (r *AdminStorage) GetAdminDashboard(ctx context.Context) (*adminModel.DashboardStats, error) {
+	stats, found, err := r.readDashboardCounters(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if found {
+		return stats, nil
 	}
 
-	return nil
-
+	return r.CountDashboardStats(ctx)
 }
 
-func (r *AdminStorage) GetAdminDashboard(ctx context.Context) (*adminModel.DashboardStats, error) {
+// CountDashboardStats computes the all-time stats by scanning users and
+// bookings.
+func (r *AdminStorage) CountDashboardStats(ctx context.Context) (*adminModel.DashboardStats, error) {
 	var stats adminModel.DashboardStats
 
 	err := r.DB.WithContext(ctx).
@@ -211,10 +279,17 @@ func (r *AdminStorage) GetAdminWallet(ctx context.Context, email string) (*admin
 	return &wallet, nil
 }
 
-func (r *AdminStorage) ListCategories(ctx context.Context) ([]models.Category, error) {
-	var categories []models.Category
+func (r *AdminStorage) ListCategories(ctx context.Context, activeOnly bool) ([]adminModel.Category, error) {
+	var categories []adminModel.Category
+
+	query := r.DB.Statement.DB.WithContext(ctx)
+	if activeOnly {
+		query = query.Where("is_active = ?", true)
+	}
 
-	err := r.DB.Statement.DB.WithContext(ctx).
+	err := query.
+		Order("display_order").
+		Order("category_name").
 		Find(&categories).Error
 
 	if err != nil {
@@ -0,0 +1,396 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+
+	adminModel "github.com/AthulKrishna2501/zyra-admin-service/internals/core/models"
+)
+
+// GetCategoryUsage returns usage figures for every live category. Vendor and
+// pending request counts are current; new requests, bookings and revenue
+// are limited to the period. Cancelled bookings count as demand but not as
+// revenue.
+func (r *AdminStorage) GetCategoryUsage(ctx context.Context, period adminModel.Period) ([]adminModel.CategoryUsage, error) {
+	var usage []adminModel.CategoryUsage
+
+	err := r.DB.WithContext(ctx).
+		Raw(`
+			WITH booking_stats AS (
+				SELECT
+					LOWER(TRIM(service)) AS service,
+					COUNT(*) AS bookings,
+					COALESCE(SUM(price) FILTER (WHERE status <> ?), 0) AS revenue
+				FROM bookings
+				WHERE created_at >= ? AND created_at < ?
+				GROUP BY 1
+			),
+			vendor_stats AS (
+				SELECT category_id, COUNT(*) AS vendors
+				FROM vendor_categories
+				GROUP BY category_id
+			),
+			request_stats AS (
+				SELECT
+					category_id,
+					COUNT(*) FILTER (WHERE status = ?) AS pending,
+					COUNT(*) FILTER (WHERE created_at >= ? AND created_at < ?) AS new_requests
+				FROM category_requests
+				GROUP BY category_id
+			)
+			SELECT
+				c.category_id,
+				c.category_name,
+				c.parent_id,
+				COALESCE(v.vendors, 0) AS approved_vendors,
+				COALESCE(rq.pending, 0) AS pending_requests,
+				COALESCE(rq.new_requests, 0) AS new_requests,
+				COALESCE(b.bookings, 0) AS bookings,
+				COALESCE(b.revenue, 0) AS revenue
+			FROM categories c
+			LEFT JOIN vendor_stats v ON v.category_id = c.category_id
+			LEFT JOIN request_stats rq ON rq.category_id = c.category_id
+			LEFT JOIN booking_stats b ON b.service = LOWER(c.category_name)
+			WHERE c.deleted_at IS NULL
+			ORDER BY c.category_name
+		`,
+			adminModel.BookingStatusCancelled, period.From, period.To,
+			adminModel.CategoryRequestPending, period.From, period.To,
+		).Scan(&usage).Error
+
+	if err != nil {
+		return nil, err
+	}
+
+	return usage, nil
+}
+
+// GetTimeSeries buckets bookings, revenue, sign-ups and admin wallet
+// movements by granularity, which must be a date_trunc unit. Buckets with no
+// activity are returned as zeros so the series has no gaps.
+func (r *AdminStorage) GetTimeSeries(ctx context.Context, period adminModel.Period, granularity string) ([]adminModel.TimeSeriesPoint, error) {
+	var points []adminModel.TimeSeriesPoint
+
+	err := r.DB.WithContext(ctx).
+		Raw(`
+			WITH series AS (
+				SELECT generate_series(
+					date_trunc(@unit, CAST(@from AS timestamptz)),
+					CAST(@to AS timestamptz) - interval '1 microsecond',
+					CAST(@step AS interval)
+				) AS bucket
+			),
+			booking_stats AS (
+				SELECT
+					date_trunc(@unit, created_at) AS bucket,
+					COUNT(*) AS bookings,
+					COALESCE(SUM(price) FILTER (WHERE status <> @cancelled), 0) AS revenue
+				FROM bookings
+				WHERE created_at >= @from AND created_at < @to
+				GROUP BY 1
+			),
+			user_stats AS (
+				SELECT
+					date_trunc(@unit, created_at) AS bucket,
+					COUNT(*) FILTER (WHERE role = @client) AS new_clients,
+					COUNT(*) FILTER (WHERE role = @vendor) AS new_vendors
+				FROM users
+				WHERE created_at >= @from AND created_at < @to
+				GROUP BY 1
+			),
+			wallet_stats AS (
+				SELECT
+					date_trunc(@unit, date) AS bucket,
+					COALESCE(SUM(amount) FILTER (WHERE type NOT IN @outflow), 0) AS wallet_inflow,
+					COALESCE(SUM(amount) FILTER (WHERE type IN @outflow), 0) AS wallet_outflow
+				FROM admin_wallet_transactions
+				WHERE date >= @from AND date < @to
+				GROUP BY 1
+			)
+			SELECT
+				s.bucket,
+				COALESCE(b.bookings, 0) AS bookings,
+				COALESCE(b.revenue, 0) AS revenue,
+				COALESCE(u.new_clients, 0) AS new_clients,
+				COALESCE(u.new_vendors, 0) AS new_vendors,
+				COALESCE(w.wallet_inflow, 0) AS wallet_inflow,
+				COALESCE(w.wallet_outflow, 0) AS wallet_outflow
+			FROM series s
+			LEFT JOIN booking_stats b ON b.bucket = s.bucket
+			LEFT JOIN user_stats u ON u.bucket = s.bucket
+			LEFT JOIN wallet_stats w ON w.bucket = s.bucket
+			ORDER BY s.bucket
+		`, map[string]interface{}{
+			"unit":      granularity,
+			"step":      "1 " + granularity,
+			"from":      period.From,
+			"to":        period.To,
+			"cancelled": adminModel.BookingStatusCancelled,
+			"client":    adminModel.RoleClient,
+			"vendor":    adminModel.RoleVendor,
+			"outflow":   adminModel.WalletOutflowTypes,
+		}).Scan(&points).Error
+
+	if err != nil {
+		return nil, err
+	}
+
+	return points, nil
+}
+
+// GetClientCohorts groups the clients who signed up in the period by month
+// and counts, for each of the following months up to months, how many of
+// them booked. Cells for months that have not started by now are left out,
+// so the matrix is dense but triangular. Months are calendar months in UTC,
+// whatever the session time zone.
+func (r *AdminStorage) GetClientCohorts(ctx context.Context, period adminModel.Period, months int, now time.Time) ([]adminModel.ClientCohortCell, error) {
+	var cells []adminModel.ClientCohortCell
+
+	err := r.DB.WithContext(ctx).
+		Raw(`
+			WITH cohorts AS (
+				SELECT user_id, date_trunc('month', created_at AT TIME ZONE 'UTC') AS cohort
+				FROM users
+				WHERE role = @client AND created_at >= @from AND created_at < @to
+			),
+			sizes AS (
+				SELECT cohort, COUNT(*) AS size FROM cohorts GROUP BY cohort
+			),
+			activity AS (
+				SELECT
+					c.cohort,
+					((EXTRACT(YEAR FROM b.created_at AT TIME ZONE 'UTC') - EXTRACT(YEAR FROM c.cohort)) * 12
+						+ EXTRACT(MONTH FROM b.created_at AT TIME ZONE 'UTC') - EXTRACT(MONTH FROM c.cohort))::int AS month_offset,
+					COUNT(DISTINCT b.client_id) AS active_clients
+				FROM cohorts c
+				JOIN bookings b ON b.client_id = c.user_id
+				WHERE b.status <> @cancelled AND b.created_at AT TIME ZONE 'UTC' >= c.cohort
+				GROUP BY 1, 2
+			)
+			SELECT
+				s.cohort,
+				s.size,
+				o.month_offset,
+				COALESCE(a.active_clients, 0) AS active_clients
+			FROM sizes s
+			CROSS JOIN generate_series(0, @months) AS o(month_offset)
+			LEFT JOIN activity a ON a.cohort = s.cohort AND a.month_offset = o.month_offset
+			WHERE s.cohort + make_interval(months => o.month_offset) <= CAST(@now AS timestamptz) AT TIME ZONE 'UTC'
+			ORDER BY s.cohort, o.month_offset
+		`, map[string]interface{}{
+			"client":    adminModel.RoleClient,
+			"cancelled": adminModel.BookingStatusCancelled,
+			"from":      period.From,
+			"to":        period.To,
+			"months":    months,
+			"now":       now,
+		}).Scan(&cells).Error
+
+	if err != nil {
+		return nil, err
+	}
+
+	return cells, nil
+}
+
+// GetFilteredDashboard computes the dashboard stats for the bookings matching
+// the filter. Bookings and revenue cover the matching bookings. Without a
+// segment filter, vendors and clients are the accounts registered before the
+// end of the range. With one, they are the distinct vendors and clients
+// behind the matching bookings.
+func (r *AdminStorage) GetFilteredDashboard(ctx context.Context, filter adminModel.DashboardFilter) (*adminModel.DashboardStats, error) {
+	var stats adminModel.DashboardStats
+
+	bookings := r.DB.WithContext(ctx).Model(&adminModel.Booking{}).Scopes(dashboardBookings(filter))
+
+	if filter.HasSegment() {
+		err := bookings.
+			Select(`
+				COUNT(DISTINCT vendor_id) AS total_vendors,
+				COUNT(DISTINCT client_id) AS total_clients,
+				COUNT(*) AS total_bookings,
+				COALESCE(SUM(price), 0) AS total_revenue
+			`).
+			Scan(&stats).Error
+		if err != nil {
+			return nil, err
+		}
+
+		return &stats, nil
+	}
+
+	err := bookings.
+		Select("COUNT(*) AS total_bookings, COALESCE(SUM(price), 0) AS total_revenue").
+		Scan(&stats).Error
+	if err != nil {
+		return nil, err
+	}
+
+	users := r.DB.WithContext(ctx).Table("users")
+	if !filter.To.IsZero() {
+		users = users.Where("created_at < ?", filter.To)
+	}
+
+	err = users.
+		Select("COUNT(*) FILTER (WHERE role = ?) AS total_vendors, COUNT(*) FILTER (WHERE role = ?) AS total_clients",
+			adminModel.RoleVendor, adminModel.RoleClient).
+		Scan(&stats).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return &stats, nil
+}
+
+// GetBookingFunnel breaks the bookings matching the filter down by funnel
+// stage, status and approval flags.
+func (r *AdminStorage) GetBookingFunnel(ctx context.Context, filter adminModel.DashboardFilter) (*adminModel.BookingFunnel, error) {
+	var funnel adminModel.BookingFunnel
+
+	bookings := r.DB.WithContext(ctx).Model(&adminModel.Booking{}).Scopes(dashboardBookings(filter))
+
+	err := bookings.Session(&gorm.Session{}).
+		Select(`
+			COUNT(*) AS created,
+			COUNT(*) FILTER (WHERE is_vendor_approved) AS vendor_approved,
+			COUNT(*) FILTER (WHERE is_vendor_approved AND is_client_approved) AS client_approved,
+			COUNT(*) FILTER (WHERE is_vendor_approved AND is_client_approved AND is_fund_released) AS fund_released,
+			PERCENTILE_CONT(0.5) WITHIN GROUP (ORDER BY EXTRACT(EPOCH FROM vendor_approved_at - created_at))
+				AS median_vendor_approval_seconds,
+			PERCENTILE_CONT(0.5) WITHIN GROUP (ORDER BY EXTRACT(EPOCH FROM client_approved_at - vendor_approved_at))
+				AS median_client_approval_seconds,
+			PERCENTILE_CONT(0.5) WITHIN GROUP (ORDER BY EXTRACT(EPOCH FROM fund_released_at - client_approved_at))
+				AS median_fund_release_seconds
+		`).
+		Scan(&funnel.Stages).Error
+	if err != nil {
+		return nil, err
+	}
+
+	err = bookings.Session(&gorm.Session{}).
+		Select("status, COUNT(*) AS bookings, COALESCE(SUM(price), 0) AS revenue").
+		Group("status").
+		Order("bookings DESC").
+		Scan(&funnel.Statuses).Error
+	if err != nil {
+		return nil, err
+	}
+
+	err = bookings.Session(&gorm.Session{}).
+		Select("is_vendor_approved, is_client_approved, is_fund_released, COUNT(*) AS bookings").
+		Group("is_vendor_approved, is_client_approved, is_fund_released").
+		Order("is_vendor_approved, is_client_approved, is_fund_released").
+		Scan(&funnel.ApprovalStates).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return &funnel, nil
+}
+
+func dashboardBookings(filter adminModel.DashboardFilter) func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		if !filter.From.IsZero() {
+			db = db.Where("created_at >= ?", filter.From)
+		}
+		if !filter.To.IsZero() {
+			db = db.Where("created_at < ?", filter.To)
+		}
+		if filter.CategoryName != "" || filter.Service != "" {
+			db = db.Where("LOWER(TRIM(service)) IN (?)", dashboardServices(db, filter))
+		}
+		if len(filter.Statuses) > 0 {
+			db = db.Where("status IN ?", filter.Statuses)
+		}
+		return db
+	}
+}
+
+// dashboardServices returns the lowercased service names a booking may have
+// to match the category and service filters. A category covers its own name
+// and the names of all its subcategories, deleted ones included so past
+// bookings still count; a service narrows that to the one name.
+func dashboardServices(db *gorm.DB, filter adminModel.DashboardFilter) *gorm.DB {
+	names := db.Session(&gorm.Session{NewDB: true})
+
+	if filter.CategoryName == "" {
+		return names.Raw("SELECT LOWER(TRIM(?))", filter.Service)
+	}
+
+	return names.Raw(`
+		WITH RECURSIVE subtree AS (
+			SELECT category_id, category_name FROM categories
+			WHERE LOWER(category_name) = LOWER(TRIM(@category))
+			UNION ALL
+			SELECT c.category_id, c.category_name FROM categories c
+			JOIN subtree s ON c.parent_id = s.category_id
+		)
+		SELECT DISTINCT LOWER(category_name) FROM subtree
+		WHERE CAST(@service AS text) = '' OR LOWER(category_name) = LOWER(TRIM(@service))
+	`, map[string]interface{}{
+		"category": filter.CategoryName,
+		"service":  filter.Service,
+	})
+}
+
+// leaderboardColumns maps the ranking options to the column they sort on.
+var leaderboardColumns = map[string]string{
+	adminModel.RankByRevenue:        "amount",
+	adminModel.RankBySpend:          "amount",
+	adminModel.RankByBookings:       "bookings",
+	adminModel.RankByCompletionRate: "completion_rate",
+}
+
+// GetLeaderboard ranks vendors or clients by their bookings in the period.
+// Ties share a rank.
+func (r *AdminStorage) GetLeaderboard(ctx context.Context, filter adminModel.LeaderboardFilter) ([]adminModel.LeaderboardEntry, int64, error) {
+	var entries []adminModel.LeaderboardEntry
+	var total int64
+
+	userColumn := "vendor_id"
+	if filter.Board == adminModel.LeaderboardClients {
+		userColumn = "client_id"
+	}
+
+	rankColumn, ok := leaderboardColumns[filter.RankBy]
+	if !ok {
+		return nil, 0, fmt.Errorf("unknown ranking %q", filter.RankBy)
+	}
+
+	aggregate := r.DB.WithContext(ctx).
+		Table("bookings b").
+		Select(fmt.Sprintf(`
+			b.%[1]s AS user_id,
+			d.first_name,
+			d.last_name,
+			COUNT(*) AS bookings,
+			COUNT(*) FILTER (WHERE b.status = ?) AS completed_bookings,
+			COALESCE(SUM(b.price) FILTER (WHERE b.status <> ?), 0) AS amount,
+			COUNT(*) FILTER (WHERE b.status = ?)::float / COUNT(*) AS completion_rate
+		`, userColumn), adminModel.BookingStatusCompleted, adminModel.BookingStatusCancelled, adminModel.BookingStatusCompleted).
+		Joins(fmt.Sprintf("LEFT JOIN user_details d ON d.user_id = b.%s", userColumn)).
+		Where("b.created_at >= ? AND b.created_at < ?", filter.Period.From, filter.Period.To).
+		Group(fmt.Sprintf("b.%s, d.first_name, d.last_name", userColumn)).
+		Having("COUNT(*) >= ?", filter.MinBookings)
+
+	if err := r.DB.WithContext(ctx).Table("(?) AS t", aggregate).Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	query := r.DB.WithContext(ctx).
+		Table("(?) AS t", aggregate).
+		Select(fmt.Sprintf("t.*, RANK() OVER (ORDER BY t.%s DESC) AS rank", rankColumn)).
+		Order(fmt.Sprintf("t.%s DESC, t.user_id", rankColumn))
+	if filter.Limit > 0 {
+		query = query.Limit(filter.Limit).Offset(filter.Offset)
+	}
+
+	if err := query.Scan(&entries).Error; err != nil {
+		return nil, 0, err
+	}
+
+	return entries, total, nil
+}
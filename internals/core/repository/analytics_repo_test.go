@@ -0,0 +1,79 @@
+package repository
+
+import (
+	"strings"
+	"testing"
+
+	adminModel "github.com/AthulKrishna2501/zyra-admin-service/internals/core/models"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+func TestDashboardBookingsServiceFilter(t *testing.T) {
+	db, err := gorm.Open(postgres.New(postgres.Config{DSN: "host=localhost"}), &gorm.Config{
+		DryRun:               true,
+		DisableAutomaticPing: true,
+	})
+	if err != nil {
+		t.Fatalf("failed to open dry-run database: %v", err)
+	}
+
+	tests := []struct {
+		name        string
+		filter      adminModel.DashboardFilter
+		want        []string
+		wantMissing []string
+	}{
+		{
+			name:        "no filter",
+			wantMissing: []string{"WHERE"},
+		},
+		{
+			name:        "service only",
+			filter:      adminModel.DashboardFilter{Service: "Catering"},
+			want:        []string{"LOWER(TRIM(service)) IN (SELECT LOWER(TRIM('Catering')))"},
+			wantMissing: []string{"categories"},
+		},
+		{
+			name:   "category covers its subcategories",
+			filter: adminModel.DashboardFilter{CategoryName: "Food"},
+			want: []string{
+				"LOWER(TRIM(service)) IN (",
+				"WITH RECURSIVE subtree",
+				"LOWER(TRIM('Food'))",
+				"JOIN subtree s ON c.parent_id = s.category_id",
+			},
+		},
+		{
+			name:   "category and service share one predicate",
+			filter: adminModel.DashboardFilter{CategoryName: "Food", Service: "Catering"},
+			want: []string{
+				"LOWER(TRIM('Food'))",
+				"LOWER(category_name) = LOWER(TRIM('Catering'))",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			query := db.ToSQL(func(tx *gorm.DB) *gorm.DB {
+				var bookings []adminModel.Booking
+				return tx.Scopes(dashboardBookings(tt.filter)).Find(&bookings)
+			})
+
+			for _, want := range tt.want {
+				if !strings.Contains(query, want) {
+					t.Errorf("query %q does not contain %q", query, want)
+				}
+			}
+			for _, missing := range tt.wantMissing {
+				if strings.Contains(query, missing) {
+					t.Errorf("query %q should not contain %q", query, missing)
+				}
+			}
+			if n := strings.Count(query, "service)) IN"); n > 1 {
+				t.Errorf("query filters on service %d times, want once", n)
+			}
+		})
+	}
+}
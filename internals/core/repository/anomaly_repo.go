@@ -0,0 +1,277 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	adminModel "github.com/AthulKrishna2501/zyra-admin-service/internals/core/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var ErrAlertAcknowledged = errors.New("alert already acknowledged")
+
+const anomalySettingsID = 1
+
+// anomalyFeed lists every money movement the anomaly rules look at as
+// (source, subject_key, user_id, kind, amount, occurred_at). Client wallet
+// credits are the wallet transactions recorded as refunded, which is how
+// fund releases and refunds are paid into a wallet; they have no ID of
+// their own, so user and time identify them.
+const anomalyFeed = `
+	SELECT
+		@admin_wallet AS source,
+		transaction_id::text AS subject_key,
+		NULL::uuid AS user_id,
+		type AS kind,
+		amount::float8 AS amount,
+		date AS occurred_at
+	FROM admin_wallet_transactions
+	UNION ALL
+	SELECT @fund_release, f.request_id::text, e.hosted_by, @fund_release, f.amount::float8, f.created_at
+	FROM fund_releases f
+	LEFT JOIN events e ON e.event_id = f.event_id
+	UNION ALL
+	SELECT @client_credit, t.user_id::text || '@' || t.date_of_payment::text, t.user_id, t.purpose, t.amount_paid::float8, t.date_of_payment
+	FROM transactions t
+	WHERE t.payment_method = 'wallet' AND t.payment_status = 'refunded'
+`
+
+// GetAnomalySettings returns the stored thresholds, or disabled defaults if
+// they have never been saved.
+func (r *AdminStorage) GetAnomalySettings(ctx context.Context) (*adminModel.AnomalySettings, error) {
+	var settings adminModel.AnomalySettings
+
+	err := r.DB.WithContext(ctx).Where("id = ?", anomalySettingsID).First(&settings).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return &adminModel.AnomalySettings{
+			ID:                        anomalySettingsID,
+			SpikeZScore:               3,
+			SpikeBaselineDays:         30,
+			SpikeMinSamples:           20,
+			RepeatedPayoutCount:       3,
+			RepeatedPayoutWindowHours: 24,
+			VelocityMaxCount:          10,
+			VelocityWindowMinutes:     60,
+		}, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	return &settings, nil
+}
+
+func (r *AdminStorage) SaveAnomalySettings(ctx context.Context, settings *adminModel.AnomalySettings) error {
+	settings.ID = anomalySettingsID
+	return r.DB.WithContext(ctx).Save(settings).Error
+}
+
+// FindAmountSpikes returns the movements in the period whose amount is at
+// least zScore standard deviations above the mean for the same source and
+// kind over the baseline days before the period.
+func (r *AdminStorage) FindAmountSpikes(ctx context.Context, period adminModel.Period, settings *adminModel.AnomalySettings) ([]adminModel.AnomalyCandidate, error) {
+	var candidates []adminModel.AnomalyCandidate
+
+	err := r.DB.WithContext(ctx).
+		Raw(`
+			WITH feed AS (`+anomalyFeed+`),
+			baseline AS (
+				SELECT
+					source,
+					kind,
+					AVG(amount) AS mean,
+					STDDEV_SAMP(amount) AS std_dev,
+					COUNT(*) AS samples
+				FROM feed
+				WHERE occurred_at >= @baseline_from AND occurred_at < @from
+				GROUP BY source, kind
+			)
+			SELECT
+				f.source,
+				f.subject_key,
+				f.user_id,
+				f.amount,
+				f.occurred_at,
+				(f.amount - b.mean) / b.std_dev AS score,
+				b.mean,
+				b.std_dev,
+				b.samples
+			FROM feed f
+			JOIN baseline b ON b.source = f.source AND b.kind = f.kind
+			WHERE f.occurred_at >= @from AND f.occurred_at < @to
+				AND b.samples >= @min_samples
+				AND b.std_dev > 0
+				AND (f.amount - b.mean) / b.std_dev >= @z_score
+			ORDER BY f.occurred_at
+		`, anomalyParams(map[string]interface{}{
+			"baseline_from": period.From.AddDate(0, 0, -settings.SpikeBaselineDays),
+			"from":          period.From,
+			"to":            period.To,
+			"min_samples":   settings.SpikeMinSamples,
+			"z_score":       settings.SpikeZScore,
+		})).Scan(&candidates).Error
+
+	if err != nil {
+		return nil, err
+	}
+
+	return candidates, nil
+}
+
+// FindMovementBursts returns, for the given sources, the movements in the
+// period that brought their user (or the whole source, for movements
+// without a user) to threshold movements within window. Only the movement
+// that crosses the threshold is returned, not the ones that follow while
+// the burst lasts, and it is keyed by the movement's own ID, so a rescan or
+// a changed window never raises the same movement twice. Counts look back
+// two windows so the movement before the period has a complete count too.
+func (r *AdminStorage) FindMovementBursts(ctx context.Context, period adminModel.Period, sources []string, window time.Duration, threshold int) ([]adminModel.AnomalyCandidate, error) {
+	var candidates []adminModel.AnomalyCandidate
+
+	err := r.DB.WithContext(ctx).
+		Raw(`
+			WITH feed AS (`+anomalyFeed+`),
+			windowed AS (
+				SELECT
+					source,
+					subject_key,
+					user_id,
+					occurred_at,
+					COUNT(*) OVER w AS count,
+					SUM(amount) OVER w AS amount
+				FROM feed
+				WHERE source IN @sources AND occurred_at >= @lookback_from AND occurred_at < @to
+				WINDOW w AS (
+					PARTITION BY source, user_id ORDER BY occurred_at
+					RANGE BETWEEN CAST(@window AS interval) PRECEDING AND CURRENT ROW
+				)
+			),
+			crossings AS (
+				SELECT *, LAG(count) OVER (PARTITION BY source, user_id ORDER BY occurred_at, subject_key) AS previous_count
+				FROM windowed
+			)
+			SELECT
+				source,
+				subject_key,
+				user_id,
+				amount,
+				occurred_at,
+				count AS score,
+				count
+			FROM crossings
+			WHERE occurred_at >= @from
+				AND count >= @threshold
+				AND (previous_count IS NULL OR previous_count < @threshold)
+			ORDER BY occurred_at
+		`, anomalyParams(map[string]interface{}{
+			"sources":       sources,
+			"lookback_from": period.From.Add(-2 * window),
+			"from":          period.From,
+			"to":            period.To,
+			"window":        fmt.Sprintf("%d seconds", int64(window.Seconds())),
+			"threshold":     threshold,
+		})).Scan(&candidates).Error
+
+	if err != nil {
+		return nil, err
+	}
+
+	return candidates, nil
+}
+
+// SaveAnomalyAlerts stores the alerts, skipping any that were raised by an
+// earlier scan. It returns the alerts that are new.
+func (r *AdminStorage) SaveAnomalyAlerts(ctx context.Context, alerts []adminModel.AnomalyAlert) ([]adminModel.AnomalyAlert, error) {
+	var created []adminModel.AnomalyAlert
+
+	for _, alert := range alerts {
+		result := r.DB.WithContext(ctx).
+			Clauses(clause.OnConflict{DoNothing: true}).
+			Create(&alert)
+		if result.Error != nil {
+			return created, result.Error
+		}
+		if result.RowsAffected > 0 {
+			created = append(created, alert)
+		}
+	}
+
+	return created, nil
+}
+
+func (r *AdminStorage) ListAnomalyAlerts(ctx context.Context, filter adminModel.AnomalyAlertFilter) ([]adminModel.AnomalyAlert, int64, error) {
+	var alerts []adminModel.AnomalyAlert
+	var total int64
+
+	query := r.DB.WithContext(ctx).Model(&adminModel.AnomalyAlert{})
+	if filter.Status != "" {
+		query = query.Where("status = ?", filter.Status)
+	}
+	if filter.Rule != "" {
+		query = query.Where("rule = ?", filter.Rule)
+	}
+	if filter.Source != "" {
+		query = query.Where("source = ?", filter.Source)
+	}
+	if filter.UserID != "" {
+		query = query.Where("user_id = ?", filter.UserID)
+	}
+	query = query.Session(&gorm.Session{})
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	err := query.Order("occurred_at DESC").Limit(filter.Limit).Offset(filter.Offset).Find(&alerts).Error
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return alerts, total, nil
+}
+
+// AcknowledgeAnomalyAlert marks an open alert as seen by an admin.
+func (r *AdminStorage) AcknowledgeAnomalyAlert(ctx context.Context, alertID, adminEmail, note string) (*adminModel.AnomalyAlert, error) {
+	var alert adminModel.AnomalyAlert
+
+	err := r.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("alert_id = ?", alertID).
+			First(&alert).Error
+		if err != nil {
+			return err
+		}
+		if alert.Status != adminModel.AnomalyAlertOpen {
+			return ErrAlertAcknowledged
+		}
+
+		now := time.Now()
+		alert.Status = adminModel.AnomalyAlertAcknowledged
+		alert.AcknowledgedBy = adminEmail
+		alert.AcknowledgedAt = &now
+		alert.Note = note
+
+		return tx.Model(&alert).Updates(map[string]interface{}{
+			"status":          alert.Status,
+			"acknowledged_by": alert.AcknowledgedBy,
+			"acknowledged_at": alert.AcknowledgedAt,
+			"note":            alert.Note,
+		}).Error
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &alert, nil
+}
+
+// anomalyParams adds the source names used by anomalyFeed to params.
+func anomalyParams(params map[string]interface{}) map[string]interface{} {
+	params["admin_wallet"] = adminModel.AnomalySourceAdminWallet
+	params["fund_release"] = adminModel.AnomalySourceFundRelease
+	params["client_credit"] = adminModel.AnomalySourceClientCredit
+	return params
+}
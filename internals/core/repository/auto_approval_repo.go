@@ -0,0 +1,123 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	adminModel "github.com/AthulKrishna2501/zyra-admin-service/internals/core/models"
+	"gorm.io/gorm"
+)
+
+const autoApprovalSettingsID = 1
+
+// GetAutoApprovalSettings returns the stored rules, or disabled defaults if
+// they have never been saved.
+func (r *AdminStorage) GetAutoApprovalSettings(ctx context.Context) (*adminModel.AutoApprovalSettings, error) {
+	var settings adminModel.AutoApprovalSettings
+
+	err := r.DB.WithContext(ctx).Where("id = ?", autoApprovalSettingsID).First(&settings).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return &adminModel.AutoApprovalSettings{ID: autoApprovalSettingsID}, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	return &settings, nil
+}
+
+func (r *AdminStorage) SaveAutoApprovalSettings(ctx context.Context, settings *adminModel.AutoApprovalSettings) error {
+	settings.ID = autoApprovalSettingsID
+	return r.DB.WithContext(ctx).Save(settings).Error
+}
+
+// ListUnevaluatedCategoryRequests returns the oldest pending requests the
+// auto-approval rules have not looked at yet.
+func (r *AdminStorage) ListUnevaluatedCategoryRequests(ctx context.Context, limit int) ([]adminModel.CategoryRequest, error) {
+	var requests []adminModel.CategoryRequest
+
+	err := r.DB.WithContext(ctx).
+		Where("status = ? AND auto_evaluated_at IS NULL", adminModel.CategoryRequestPending).
+		Order("created_at").
+		Limit(limit).
+		Find(&requests).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return requests, nil
+}
+
+func (r *AdminStorage) GetVendorStanding(ctx context.Context, vendorID string) (*adminModel.VendorStanding, error) {
+	var standing adminModel.VendorStanding
+
+	result := r.DB.WithContext(ctx).
+		Raw(`
+			SELECT
+				u.created_at AS account_created_at,
+				(SELECT COUNT(*) FROM bookings b WHERE b.vendor_id = u.user_id AND b.status = ?) AS completed_bookings,
+				(SELECT COUNT(*) FROM bookings b WHERE b.vendor_id = u.user_id AND b.status = ?) AS disputes
+			FROM users u
+			WHERE u.user_id = ?
+		`, adminModel.BookingStatusCompleted, adminModel.BookingStatusDisputed, vendorID).
+		Scan(&standing)
+
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return nil, gorm.ErrRecordNotFound
+	}
+
+	return &standing, nil
+}
+
+// RecordAutoApprovalEvaluation stores the evaluation and marks the request
+// as evaluated so the worker does not pick it up again.
+func (r *AdminStorage) RecordAutoApprovalEvaluation(ctx context.Context, evaluation *adminModel.AutoApprovalEvaluation) error {
+	return r.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(evaluation).Error; err != nil {
+			return err
+		}
+
+		return tx.Model(&adminModel.CategoryRequest{}).
+			Where("request_id = ?", evaluation.RequestID).
+			Update("auto_evaluated_at", time.Now()).Error
+	})
+}
+
+// AutoApproveCategoryRequest approves the request as decided by the rules
+// and records the evaluation that explains it in one transaction, so an
+// approval never exists without its audit entry.
+func (r *AdminStorage) AutoApproveCategoryRequest(ctx context.Context, decision adminModel.CategoryDecision, evaluation *adminModel.AutoApprovalEvaluation) error {
+	return r.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		txRepo := &AdminStorage{DB: tx}
+		if err := txRepo.DecideCategoryRequest(ctx, evaluation.RequestID.String(), decision); err != nil {
+			return err
+		}
+
+		return txRepo.RecordAutoApprovalEvaluation(ctx, evaluation)
+	})
+}
+
+func (r *AdminStorage) ListAutoApprovalEvaluations(ctx context.Context, requestID string, limit, offset int) ([]adminModel.AutoApprovalEvaluation, int64, error) {
+	var evaluations []adminModel.AutoApprovalEvaluation
+	var total int64
+
+	query := r.DB.WithContext(ctx).Model(&adminModel.AutoApprovalEvaluation{})
+	if requestID != "" {
+		query = query.Where("request_id = ?", requestID)
+	}
+	query = query.Session(&gorm.Session{})
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	err := query.Order("created_at DESC").Limit(limit).Offset(offset).Find(&evaluations).Error
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return evaluations, total, nil
+}
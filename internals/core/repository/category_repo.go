@@ -0,0 +1,424 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	adminModel "github.com/AthulKrishna2501/zyra-admin-service/internals/core/models"
+	"github.com/AthulKrishna2501/zyra-vendor-service/internals/core/models"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgconn"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var (
+	ErrCategoryExists      = errors.New("category name already exists")
+	ErrParentNotFound      = errors.New("parent category not found")
+	ErrCategoryCycle       = errors.New("category cannot be moved below itself or one of its subcategories")
+	ErrCategoryHasChildren = errors.New("category still has subcategories")
+	ErrSlugExists          = errors.New("category slug already exists")
+	ErrSubcategoryConflict = errors.New("source and target categories have subcategories with the same name")
+)
+
+// CategoryNameIndex is the case-insensitive unique index on sibling category
+// names. It backs up siblingNameTaken when two writers race.
+const CategoryNameIndex = "idx_categories_parent_name"
+
+func (r *AdminStorage) GetCategory(ctx context.Context, categoryID string) (*adminModel.Category, error) {
+	var category adminModel.Category
+
+	err := r.DB.WithContext(ctx).Where("category_id = ?", categoryID).First(&category).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return &category, nil
+}
+
+func (r *AdminStorage) RenameCategory(ctx context.Context, categoryID, name string) error {
+	err := r.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var category adminModel.Category
+		if err := tx.Where("category_id = ?", categoryID).First(&category).Error; err != nil {
+			return err
+		}
+
+		taken, err := siblingNameTaken(tx, name, category.ParentID, &category.CategoryID)
+		if err != nil {
+			return err
+		}
+		if taken {
+			return ErrCategoryExists
+		}
+
+		return tx.Model(&category).Update("category_name", name).Error
+	})
+
+	return categoryConstraintError(err)
+}
+
+func (r *AdminStorage) DeleteCategory(ctx context.Context, categoryID string) error {
+	return r.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var children int64
+		if err := tx.Model(&adminModel.Category{}).Where("parent_id = ?", categoryID).Count(&children).Error; err != nil {
+			return err
+		}
+		if children > 0 {
+			return ErrCategoryHasChildren
+		}
+
+		result := tx.Where("category_id = ?", categoryID).Delete(&adminModel.Category{})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+
+		return nil
+	})
+}
+
+func (r *AdminStorage) RestoreCategory(ctx context.Context, categoryID string) error {
+	err := r.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var category adminModel.Category
+		err := tx.Unscoped().
+			Where("category_id = ? AND deleted_at IS NOT NULL", categoryID).
+			First(&category).Error
+		if err != nil {
+			return err
+		}
+
+		if category.ParentID != nil {
+			if err := requireActiveCategory(tx, category.ParentID.String()); err != nil {
+				return err
+			}
+		}
+
+		taken, err := siblingNameTaken(tx, category.CategoryName, category.ParentID, nil)
+		if err != nil {
+			return err
+		}
+		if taken {
+			return ErrCategoryExists
+		}
+
+		if category.Slug != "" {
+			taken, err := slugTaken(tx, category.Slug, &category.CategoryID)
+			if err != nil {
+				return err
+			}
+			if taken {
+				return ErrSlugExists
+			}
+		}
+
+		return tx.Unscoped().
+			Model(&adminModel.Category{}).
+			Where("category_id = ?", categoryID).
+			Update("deleted_at", nil).Error
+	})
+
+	return categoryConstraintError(err)
+}
+
+// MergeCategories moves every vendor membership, pending category request
+// and subcategory from the source category to the target and soft deletes
+// the source, all in one transaction. Rows that would duplicate an existing
+// membership or request on the target are dropped instead of moved.
+func (r *AdminStorage) MergeCategories(ctx context.Context, sourceID, targetID string) (*adminModel.CategoryMergeResult, error) {
+	result := &adminModel.CategoryMergeResult{}
+
+	err := r.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var categories []adminModel.Category
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("category_id IN ?", []string{sourceID, targetID}).
+			Find(&categories).Error
+		if err != nil {
+			return err
+		}
+		if len(categories) != 2 {
+			return gorm.ErrRecordNotFound
+		}
+
+		inSubtree, err := isInSubtree(tx, sourceID, targetID)
+		if err != nil {
+			return err
+		}
+		if inSubtree {
+			return ErrCategoryCycle
+		}
+
+		// Children of the source move under the target, so their names must
+		// not clash with the target's own children.
+		var conflicts []string
+		err = tx.Model(&adminModel.Category{}).
+			Where("parent_id = ?", sourceID).
+			Where("LOWER(category_name) IN (?)", tx.Model(&adminModel.Category{}).
+				Select("LOWER(category_name)").
+				Where("parent_id = ?", targetID)).
+			Order("category_name").
+			Pluck("category_name", &conflicts).Error
+		if err != nil {
+			return err
+		}
+		if len(conflicts) > 0 {
+			return fmt.Errorf("%w: %s", ErrSubcategoryConflict, strings.Join(conflicts, ", "))
+		}
+
+		moved, err := moveCategoryRows(tx, &models.VendorCategory{}, "vendor_categories", "", sourceID, targetID)
+		if err != nil {
+			return err
+		}
+		result.MovedVendors = moved
+
+		if _, err := moveCategoryRows(tx, &adminModel.HiddenVendorCategory{}, "hidden_vendor_categories", "", sourceID, targetID); err != nil {
+			return err
+		}
+
+		// Decided requests stay on the source category as history.
+		moved, err = moveCategoryRows(tx, &adminModel.CategoryRequest{}, "category_requests", "status = 'pending'", sourceID, targetID)
+		if err != nil {
+			return err
+		}
+		result.MovedRequests = moved
+
+		err = tx.Model(&adminModel.Category{}).
+			Where("parent_id = ?", sourceID).
+			Update("parent_id", targetID).Error
+		if err != nil {
+			return err
+		}
+
+		return tx.Where("category_id = ?", sourceID).Delete(&adminModel.Category{}).Error
+	})
+
+	if err != nil {
+		return nil, categoryConstraintError(err)
+	}
+
+	return result, nil
+}
+
+// MoveCategory re-parents a category, or makes it a root when parentID is
+// nil. Moving a category below itself or one of its descendants is refused.
+func (r *AdminStorage) MoveCategory(ctx context.Context, categoryID string, parentID *uuid.UUID) error {
+	err := r.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var category adminModel.Category
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("category_id = ?", categoryID).
+			First(&category).Error
+		if err != nil {
+			return err
+		}
+
+		if parentID != nil {
+			if err := requireActiveCategory(tx, parentID.String()); err != nil {
+				return err
+			}
+
+			inSubtree, err := isInSubtree(tx, categoryID, parentID.String())
+			if err != nil {
+				return err
+			}
+			if inSubtree {
+				return ErrCategoryCycle
+			}
+		}
+
+		taken, err := siblingNameTaken(tx, category.CategoryName, parentID, &category.CategoryID)
+		if err != nil {
+			return err
+		}
+		if taken {
+			return ErrCategoryExists
+		}
+
+		return tx.Model(&category).Update("parent_id", parentID).Error
+	})
+
+	return categoryConstraintError(err)
+}
+
+func (r *AdminStorage) SlugExists(ctx context.Context, slug string) (bool, error) {
+	return slugTaken(r.DB.WithContext(ctx), slug, nil)
+}
+
+func (r *AdminStorage) UpdateCategoryDetails(ctx context.Context, categoryID string, update adminModel.CategoryDetailsUpdate) error {
+	err := r.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var category adminModel.Category
+		if err := tx.Where("category_id = ?", categoryID).First(&category).Error; err != nil {
+			return err
+		}
+
+		updates := map[string]interface{}{}
+		if update.Description != nil {
+			updates["description"] = *update.Description
+		}
+		if update.IconURL != nil {
+			updates["icon_url"] = *update.IconURL
+		}
+		if update.DisplayOrder != nil {
+			updates["display_order"] = *update.DisplayOrder
+		}
+		if update.IsActive != nil {
+			updates["is_active"] = *update.IsActive
+		}
+		if update.CommissionRate != nil {
+			updates["commission_rate"] = *update.CommissionRate
+		}
+		if update.Slug != nil {
+			if *update.Slug != "" {
+				taken, err := slugTaken(tx, *update.Slug, &category.CategoryID)
+				if err != nil {
+					return err
+				}
+				if taken {
+					return ErrSlugExists
+				}
+			}
+			updates["slug"] = *update.Slug
+		}
+
+		if len(updates) == 0 {
+			return nil
+		}
+
+		return tx.Model(&category).Updates(updates).Error
+	})
+
+	return categoryConstraintError(err)
+}
+
+// CreateCategories inserts the categories in the given order inside one
+// transaction, so a parent must come before its children. Either every
+// category is created or none is.
+func (r *AdminStorage) CreateCategories(ctx context.Context, categories []*adminModel.Category) error {
+	return r.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		txRepo := &AdminStorage{DB: tx}
+		for _, category := range categories {
+			if err := txRepo.CreateCategory(ctx, category); err != nil {
+				return fmt.Errorf("category %q: %w", category.CategoryName, err)
+			}
+		}
+
+		return nil
+	})
+}
+
+func slugTaken(tx *gorm.DB, slug string, excludeID *uuid.UUID) (bool, error) {
+	query := tx.Model(&adminModel.Category{}).Where("slug = ?", slug)
+	if excludeID != nil {
+		query = query.Where("category_id <> ?", *excludeID)
+	}
+
+	var count int64
+	if err := query.Count(&count).Error; err != nil {
+		return false, err
+	}
+
+	return count > 0, nil
+}
+
+// isInSubtree reports whether candidateID is rootID itself or one of its
+// descendants. Soft deleted categories are included so a cycle cannot be
+// introduced through a category that is later restored.
+func isInSubtree(tx *gorm.DB, rootID, candidateID string) (bool, error) {
+	var count int64
+
+	err := tx.Raw(`
+		WITH RECURSIVE subtree AS (
+			SELECT category_id FROM categories WHERE category_id = ?
+			UNION ALL
+			SELECT c.category_id FROM categories c
+			JOIN subtree s ON c.parent_id = s.category_id
+		)
+		SELECT COUNT(*) FROM subtree WHERE category_id = ?
+	`, rootID, candidateID).Scan(&count).Error
+
+	if err != nil {
+		return false, err
+	}
+
+	return count > 0, nil
+}
+
+func requireActiveCategory(tx *gorm.DB, categoryID string) error {
+	var count int64
+	if err := tx.Model(&adminModel.Category{}).Where("category_id = ?", categoryID).Count(&count).Error; err != nil {
+		return err
+	}
+	if count == 0 {
+		return ErrParentNotFound
+	}
+
+	return nil
+}
+
+// siblingNameTaken checks whether an active category with the same name,
+// ignoring case, already exists under the same parent, ignoring excludeID.
+func siblingNameTaken(tx *gorm.DB, name string, parentID *uuid.UUID, excludeID *uuid.UUID) (bool, error) {
+	query := tx.Model(&adminModel.Category{}).Where("LOWER(category_name) = LOWER(?)", name)
+
+	if parentID == nil {
+		query = query.Where("parent_id IS NULL")
+	} else {
+		query = query.Where("parent_id = ?", *parentID)
+	}
+
+	if excludeID != nil {
+		query = query.Where("category_id <> ?", *excludeID)
+	}
+
+	var count int64
+	if err := query.Count(&count).Error; err != nil {
+		return false, err
+	}
+
+	return count > 0, nil
+}
+
+// categoryConstraintError turns a unique violation on the category name or
+// slug index into the matching sentinel error, for inserts and updates that
+// lost a race against the checks done earlier in the transaction.
+func categoryConstraintError(err error) error {
+	var pgErr *pgconn.PgError
+	if !errors.As(err, &pgErr) || pgErr.Code != "23505" {
+		return err
+	}
+
+	switch pgErr.ConstraintName {
+	case CategoryNameIndex:
+		return ErrCategoryExists
+	case "idx_categories_slug":
+		return ErrSlugExists
+	default:
+		return err
+	}
+}
+
+// moveCategoryRows re-points the rows of table matching scope from the
+// source to the target category, dropping rows whose vendor already has a
+// matching row on the target.
+func moveCategoryRows(tx *gorm.DB, model interface{}, table, scope, sourceID, targetID string) (int64, error) {
+	if scope == "" {
+		scope = "TRUE"
+	}
+
+	err := tx.Where("category_id = ?", sourceID).
+		Where(scope).
+		Where("vendor_id IN (SELECT vendor_id FROM "+table+" WHERE category_id = ? AND "+scope+")", targetID).
+		Delete(model).Error
+	if err != nil {
+		return 0, err
+	}
+
+	result := tx.Model(model).Where("category_id = ?", sourceID).Where(scope).Update("category_id", targetID)
+	if result.Error != nil {
+		return 0, result.Error
+	}
+
+	return result.RowsAffected, nil
+}
@@ -0,0 +1,233 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	adminModel "github.com/AthulKrishna2501/zyra-admin-service/internals/core/models"
+	"github.com/AthulKrishna2501/zyra-vendor-service/internals/core/models"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var (
+	ErrRequestAlreadyDecided = errors.New("category request has already been decided")
+	ErrRequestAssigned       = errors.New("category request is assigned to another admin")
+)
+
+func (r *AdminStorage) GetCategoryRequest(ctx context.Context, requestID string) (*adminModel.CategoryRequest, error) {
+	var request adminModel.CategoryRequest
+
+	err := r.DB.WithContext(ctx).Where("request_id = ?", requestID).First(&request).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return &request, nil
+}
+
+func (r *AdminStorage) FindPendingCategoryRequest(ctx context.Context, vendorID, categoryID string) (*adminModel.CategoryRequest, error) {
+	var request adminModel.CategoryRequest
+
+	err := r.DB.WithContext(ctx).
+		Where("vendor_id = ? AND category_id = ? AND status = ?", vendorID, categoryID, adminModel.CategoryRequestPending).
+		Order("created_at").
+		First(&request).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return &request, nil
+}
+
+// DecideCategoryRequest records the decision on exactly one pending request.
+// A request assigned to someone other than decision.DecidedBy is refused.
+// Approval adds the vendor to the category in the same transaction, and
+// every decision is appended to the request's history.
+func (r *AdminStorage) DecideCategoryRequest(ctx context.Context, requestID string, decision adminModel.CategoryDecision) error {
+	return r.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var request adminModel.CategoryRequest
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("request_id = ?", requestID).
+			First(&request).Error
+		if err != nil {
+			return err
+		}
+
+		if request.Status != adminModel.CategoryRequestPending {
+			return ErrRequestAlreadyDecided
+		}
+
+		if request.AssignedTo != "" && request.AssignedTo != decision.DecidedBy {
+			return ErrRequestAssigned
+		}
+
+		if decision.Status == adminModel.CategoryRequestApproved {
+			var existing int64
+			err := tx.Model(&models.VendorCategory{}).
+				Where("vendor_id = ? AND category_id = ?", request.VendorID, request.CategoryID).
+				Count(&existing).Error
+			if err != nil {
+				return err
+			}
+
+			if existing == 0 {
+				txRepo := &AdminStorage{DB: tx}
+				if err := txRepo.AddVendorCategory(ctx, request.VendorID.String(), request.CategoryID.String()); err != nil {
+					return err
+				}
+			}
+		}
+
+		err = tx.Model(&adminModel.CategoryRequest{}).
+			Where("request_id = ?", requestID).
+			Updates(map[string]interface{}{
+				"status":      decision.Status,
+				"reason_code": decision.ReasonCode,
+				"notes":       decision.Notes,
+				"decided_by":  decision.DecidedBy,
+				"decided_at":  time.Now(),
+			}).Error
+		if err != nil {
+			return err
+		}
+
+		return tx.Create(&adminModel.CategoryRequestDecision{
+			RequestID:  request.RequestID,
+			Status:     decision.Status,
+			ReasonCode: decision.ReasonCode,
+			Notes:      decision.Notes,
+			DecidedBy:  decision.DecidedBy,
+		}).Error
+	})
+}
+
+func (r *AdminStorage) GetCategoryRequestDecisions(ctx context.Context, requestIDs []uuid.UUID) ([]adminModel.CategoryRequestDecision, error) {
+	var decisions []adminModel.CategoryRequestDecision
+	if len(requestIDs) == 0 {
+		return decisions, nil
+	}
+
+	err := r.DB.WithContext(ctx).
+		Where("request_id IN ?", requestIDs).
+		Order("created_at").
+		Find(&decisions).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return decisions, nil
+}
+
+func (r *AdminStorage) GetVendorCategoryRequests(ctx context.Context, vendorID, categoryID string) ([]adminModel.CategoryRequest, error) {
+	var requests []adminModel.CategoryRequest
+
+	query := r.DB.WithContext(ctx).
+		Joins("JOIN categories c ON c.category_id = category_requests.category_id").
+		Select("category_requests.*, c.category_name as category_name").
+		Where("category_requests.vendor_id = ?", vendorID)
+
+	if categoryID != "" {
+		query = query.Where("category_requests.category_id = ?", categoryID)
+	}
+
+	err := query.Order("category_requests.created_at DESC").Find(&requests).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return requests, nil
+}
+
+// CountCategoryRequestsByAge groups the requests matching the filter into SLA
+// buckets. A pending request ages until now, a decided one until its decision.
+func (r *AdminStorage) CountCategoryRequestsByAge(ctx context.Context, filter adminModel.CategoryRequestFilter, dueSoon, overdue time.Duration) ([]adminModel.CategoryRequestAgeBucket, error) {
+	var buckets []adminModel.CategoryRequestAgeBucket
+
+	bucket := `CASE
+		WHEN COALESCE(category_requests.decided_at, NOW()) - category_requests.created_at < make_interval(secs => ?) THEN ?
+		WHEN COALESCE(category_requests.decided_at, NOW()) - category_requests.created_at < make_interval(secs => ?) THEN ?
+		ELSE ? END`
+
+	err := categoryRequestQuery(r.DB.WithContext(ctx), filter).
+		Select("("+bucket+") AS bucket, COUNT(*) AS count",
+			dueSoon.Seconds(), adminModel.SLABucketOnTrack,
+			overdue.Seconds(), adminModel.SLABucketDueSoon,
+			adminModel.SLABucketOverdue).
+		Group("bucket").
+		Scan(&buckets).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return buckets, nil
+}
+
+// AssignCategoryRequest claims a pending request for one admin so two
+// reviewers do not work on it at once. An empty assignee releases it. A
+// request held by someone else is only taken over when reassign is set.
+func (r *AdminStorage) AssignCategoryRequest(ctx context.Context, requestID, assignee string, reassign bool) (*adminModel.CategoryRequest, error) {
+	var request adminModel.CategoryRequest
+
+	err := r.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("request_id = ?", requestID).
+			First(&request).Error
+		if err != nil {
+			return err
+		}
+
+		if request.Status != adminModel.CategoryRequestPending {
+			return ErrRequestAlreadyDecided
+		}
+
+		if request.AssignedTo != "" && request.AssignedTo != assignee && !reassign {
+			return ErrRequestAssigned
+		}
+
+		var assignedAt *time.Time
+		if assignee != "" {
+			now := time.Now()
+			assignedAt = &now
+		}
+
+		request.AssignedTo = assignee
+		request.AssignedAt = assignedAt
+
+		return tx.Model(&adminModel.CategoryRequest{}).
+			Where("request_id = ?", requestID).
+			Updates(map[string]interface{}{
+				"assigned_to": assignee,
+				"assigned_at": assignedAt,
+			}).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &request, nil
+}
+
+func categoryRequestQuery(db *gorm.DB, filter adminModel.CategoryRequestFilter) *gorm.DB {
+	query := db.Model(&adminModel.CategoryRequest{})
+
+	if filter.Status != "" {
+		query = query.Where("category_requests.status = ?", filter.Status)
+	}
+	if filter.CategoryID != "" {
+		query = query.Where("category_requests.category_id = ?", filter.CategoryID)
+	}
+	if filter.VendorID != "" {
+		query = query.Where("category_requests.vendor_id = ?", filter.VendorID)
+	}
+	if filter.AssignedTo != "" {
+		query = query.Where("category_requests.assigned_to = ?", filter.AssignedTo)
+	}
+	if filter.Unassigned {
+		query = query.Where("COALESCE(category_requests.assigned_to, '') = ''")
+	}
+
+	return query
+}
@@ -0,0 +1,123 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+
+	adminModel "github.com/AthulKrishna2501/zyra-admin-service/internals/core/models"
+	"gorm.io/gorm"
+)
+
+// readDashboardCounters adds the pending deltas to the counters. It is a
+// single statement, so it sees a compaction either entirely or not at all.
+func (r *AdminStorage) readDashboardCounters(ctx context.Context) (*adminModel.DashboardStats, bool, error) {
+	var counters []adminModel.DashboardCounter
+	err := r.DB.WithContext(ctx).
+		Raw(`
+			SELECT name, SUM(value)::bigint AS value FROM (
+				SELECT name, value FROM dashboard_counters
+				UNION ALL
+				SELECT name, delta FROM dashboard_counter_deltas
+			) totals
+			GROUP BY name
+		`).Scan(&counters).Error
+	if err != nil {
+		return nil, false, err
+	}
+	if len(counters) == 0 {
+		return nil, false, nil
+	}
+
+	return countersToStats(counters), true, nil
+}
+
+// ReconcileDashboardCounters recounts users and bookings and corrects any
+// drift in the counters, returning the values before and after. The count
+// and the counters are read from one REPEATABLE READ snapshot, so writes
+// carry on meanwhile: those the snapshot misses add their own deltas, and
+// the correction is appended as a delta as well.
+func (r *AdminStorage) ReconcileDashboardCounters(ctx context.Context) (before, after *adminModel.DashboardStats, err error) {
+	err = r.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		txRepo := &AdminStorage{DB: tx}
+
+		var found bool
+		before, found, err = txRepo.readDashboardCounters(ctx)
+		if err != nil {
+			return err
+		}
+		if !found {
+			before = &adminModel.DashboardStats{}
+		}
+
+		after, err = txRepo.CountDashboardStats(ctx)
+		if err != nil {
+			return err
+		}
+
+		corrections := counterCorrections(before, after)
+		if len(corrections) == 0 {
+			return nil
+		}
+
+		return tx.Create(&corrections).Error
+	}, &sql.TxOptions{Isolation: sql.LevelRepeatableRead})
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return before, after, nil
+}
+
+// CompactDashboardCounters folds the pending deltas into the counters and
+// deletes them, in one statement.
+func (r *AdminStorage) CompactDashboardCounters(ctx context.Context) error {
+	return r.DB.WithContext(ctx).Exec(`
+		WITH moved AS (
+			DELETE FROM dashboard_counter_deltas RETURNING name, delta
+		), totals AS (
+			SELECT name, SUM(delta) AS delta FROM moved GROUP BY name
+		)
+		INSERT INTO dashboard_counters (name, value, updated_at)
+		SELECT name, delta, NOW() FROM totals
+		ON CONFLICT (name) DO UPDATE SET value = dashboard_counters.value + EXCLUDED.value, updated_at = NOW()
+	`).Error
+}
+
+// counterCorrections returns the deltas that take the counters from the
+// values in current to those in want.
+func counterCorrections(current, want *adminModel.DashboardStats) []adminModel.DashboardCounterDelta {
+	diffs := []adminModel.DashboardCounterDelta{
+		{Name: adminModel.CounterTotalVendors, Delta: int64(want.TotalVendors) - int64(current.TotalVendors)},
+		{Name: adminModel.CounterTotalClients, Delta: int64(want.TotalClients) - int64(current.TotalClients)},
+		{Name: adminModel.CounterTotalBookings, Delta: int64(want.TotalBookings) - int64(current.TotalBookings)},
+		{Name: adminModel.CounterTotalRevenue, Delta: want.TotalRevenue - current.TotalRevenue},
+	}
+
+	var corrections []adminModel.DashboardCounterDelta
+	for _, diff := range diffs {
+		if diff.Delta != 0 {
+			corrections = append(corrections, diff)
+		}
+	}
+
+	return corrections
+}
+
+func countersToStats(counters []adminModel.DashboardCounter) *adminModel.DashboardStats {
+	var stats adminModel.DashboardStats
+	for _, c := range counters {
+		switch c.Name {
+		case adminModel.CounterTotalVendors:
+			stats.TotalVendors = int32(c.Value)
+		case adminModel.CounterTotalClients:
+			stats.TotalClients = int32(c.Value)
+		case adminModel.CounterTotalBookings:
+			stats.TotalBookings = int32(c.Value)
+		case adminModel.CounterTotalRevenue:
+			stats.TotalRevenue = c.Value
+		}
+	}
+
+	return &stats
+}
@@ -0,0 +1,125 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"reflect"
+	"testing"
+
+	adminModel "github.com/AthulKrishna2501/zyra-admin-service/internals/core/models"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+func TestCounterCorrections(t *testing.T) {
+	tests := []struct {
+		name    string
+		current adminModel.DashboardStats
+		want    adminModel.DashboardStats
+		expect  []adminModel.DashboardCounterDelta
+	}{
+		{
+			name:    "in step",
+			current: adminModel.DashboardStats{TotalVendors: 2, TotalClients: 5, TotalBookings: 9, TotalRevenue: 900},
+			want:    adminModel.DashboardStats{TotalVendors: 2, TotalClients: 5, TotalBookings: 9, TotalRevenue: 900},
+		},
+		{
+			name:    "drift both ways",
+			current: adminModel.DashboardStats{TotalVendors: 3, TotalClients: 5, TotalBookings: 9, TotalRevenue: 900},
+			want:    adminModel.DashboardStats{TotalVendors: 2, TotalClients: 5, TotalBookings: 11, TotalRevenue: 1250},
+			expect: []adminModel.DashboardCounterDelta{
+				{Name: adminModel.CounterTotalVendors, Delta: -1},
+				{Name: adminModel.CounterTotalBookings, Delta: 2},
+				{Name: adminModel.CounterTotalRevenue, Delta: 350},
+			},
+		},
+		{
+			name: "unseeded counters",
+			want: adminModel.DashboardStats{TotalClients: 4},
+			expect: []adminModel.DashboardCounterDelta{
+				{Name: adminModel.CounterTotalClients, Delta: 4},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := counterCorrections(&tt.current, &tt.want); !reflect.DeepEqual(got, tt.expect) {
+				t.Errorf("counterCorrections() = %+v, want %+v", got, tt.expect)
+			}
+		})
+	}
+}
+
+// BenchmarkDashboard compares reading the dashboard from the counters with
+// counting the tables, as the number of bookings grows. It needs a migrated
+// scratch database in ADMIN_BENCH_DB_URL:
+//
+//	ADMIN_BENCH_DB_URL=postgres://... go test ./internals/core/repository -run '^$' -bench Dashboard
+//
+// Bookings are seeded inside a transaction that is rolled back afterwards,
+// so the database is left as it was found. The deltas the seed adds are
+// compacted before each measurement, as the reconciler would.
+//
+// No results are checked in, as they depend on the database host; run it
+// against a database sized like production before changing the counters.
+// With the deltas compacted, counters/N reads four rows whatever the size
+// and should stay flat as N grows, while count/N scans bookings and grows
+// with it.
+func BenchmarkDashboard(b *testing.B) {
+	dsn := os.Getenv("ADMIN_BENCH_DB_URL")
+	if dsn == "" {
+		b.Skip("ADMIN_BENCH_DB_URL is not set")
+	}
+
+	db, err := gorm.Open(postgres.Open(dsn), &gorm.Config{Logger: logger.Default.LogMode(logger.Silent)})
+	if err != nil {
+		b.Fatalf("failed to connect: %v", err)
+	}
+
+	tx := db.Begin()
+	if tx.Error != nil {
+		b.Fatalf("failed to begin: %v", tx.Error)
+	}
+	defer tx.Rollback()
+
+	ctx := context.Background()
+	repo := &AdminStorage{DB: tx}
+
+	seeded := 0
+	for _, size := range []int{10000, 100000, 1000000} {
+		err := tx.Exec(`
+			INSERT INTO bookings (client_id, vendor_id, service, date, status, price, created_at, updated_at)
+			SELECT gen_random_uuid(), gen_random_uuid(), 'bench', CURRENT_DATE + (g % 90),
+				CASE WHEN g % 10 = 0 THEN ? ELSE ? END,
+				500 + g % 5000, NOW() - random() * interval '365 days', NOW()
+			FROM generate_series(1, ?) g
+		`, adminModel.BookingStatusCancelled, adminModel.BookingStatusCompleted, size-seeded).Error
+		if err != nil {
+			b.Fatalf("seeding %d bookings failed: %v", size, err)
+		}
+		seeded = size
+
+		if err := repo.CompactDashboardCounters(ctx); err != nil {
+			b.Fatalf("compacting counters failed: %v", err)
+		}
+
+		b.Run(fmt.Sprintf("counters/%d", size), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				if _, err := repo.GetAdminDashboard(ctx); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+
+		b.Run(fmt.Sprintf("count/%d", size), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				if _, err := repo.CountDashboardStats(ctx); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
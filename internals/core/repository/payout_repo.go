@@ -0,0 +1,96 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	adminModel "github.com/AthulKrishna2501/zyra-admin-service/internals/core/models"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// HoldPayouts records an active payout hold for the user and parks their
+// pending fund release requests as on_hold. It returns the number of fund
+// release requests that were parked.
+func (r *AdminStorage) HoldPayouts(ctx context.Context, userID, reason string) (int64, error) {
+	userUUID, err := uuid.Parse(userID)
+	if err != nil {
+		return 0, err
+	}
+
+	var held int64
+	err = r.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var hold adminModel.PayoutHold
+		err := tx.Where("user_id = ? AND released_at IS NULL", userUUID).First(&hold).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			hold = adminModel.PayoutHold{UserID: userUUID, Reason: reason}
+			if err := tx.Create(&hold).Error; err != nil {
+				return err
+			}
+		} else if err != nil {
+			return err
+		}
+
+		result := tx.Model(&adminModel.FundRelease{}).
+			Where("status = ?", adminModel.FundReleaseStatusPending).
+			Where("event_id IN (SELECT event_id FROM events WHERE hosted_by = ?)", userUUID).
+			Update("status", adminModel.FundReleaseStatusOnHold)
+		if result.Error != nil {
+			return result.Error
+		}
+
+		held = result.RowsAffected
+		return nil
+	})
+
+	if err != nil {
+		return 0, err
+	}
+
+	return held, nil
+}
+
+func (r *AdminStorage) ReleasePayoutHolds(ctx context.Context, userID string) (int64, error) {
+	var released int64
+
+	err := r.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Model(&adminModel.PayoutHold{}).
+			Where("user_id = ? AND released_at IS NULL", userID).
+			Update("released_at", time.Now()).Error
+		if err != nil {
+			return err
+		}
+
+		result := tx.Model(&adminModel.FundRelease{}).
+			Where("status = ?", adminModel.FundReleaseStatusOnHold).
+			Where("event_id IN (SELECT event_id FROM events WHERE hosted_by = ?)", userID).
+			Update("status", adminModel.FundReleaseStatusPending)
+		if result.Error != nil {
+			return result.Error
+		}
+
+		released = result.RowsAffected
+		return nil
+	})
+
+	if err != nil {
+		return 0, err
+	}
+
+	return released, nil
+}
+
+func (r *AdminStorage) HasActivePayoutHold(ctx context.Context, userID string) (bool, error) {
+	var count int64
+	err := r.DB.WithContext(ctx).
+		Model(&adminModel.PayoutHold{}).
+		Where("user_id = ? AND released_at IS NULL", userID).
+		Count(&count).Error
+
+	if err != nil {
+		return false, err
+	}
+
+	return count > 0, nil
+}
@@ -0,0 +1,218 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	adminModel "github.com/AthulKrishna2501/zyra-admin-service/internals/core/models"
+	auth "github.com/AthulKrishna2501/zyra-auth-service/internals/core/models"
+	clientModel "github.com/AthulKrishna2501/zyra-client-service/internals/core/models"
+	"github.com/AthulKrishna2501/zyra-vendor-service/internals/core/models"
+	"gorm.io/gorm"
+)
+
+var secretColumnMarkers = []string{"password", "token", "secret", "otp"}
+
+// adminActorColumns hold the email of the admin who acted on a record. The
+// records are kept as an audit trail when that admin's data is erased; only
+// the email is replaced.
+var adminActorColumns = []struct {
+	model  interface{}
+	column string
+}{
+	{&adminModel.CategoryRequest{}, "decided_by"},
+	{&adminModel.CategoryRequest{}, "assigned_to"},
+	{&adminModel.CategoryRequestDecision{}, "decided_by"},
+	{&adminModel.VendorVerification{}, "reviewed_by"},
+	{&adminModel.CategoryRevocation{}, "revoked_by"},
+	{&adminModel.RoleChange{}, "changed_by"},
+	{&adminModel.DataErasure{}, "requested_by"},
+}
+
+func (r *AdminStorage) ExportUserData(ctx context.Context, userID string) (*adminModel.UserDataExport, error) {
+	db := r.DB.WithContext(ctx)
+	export := &adminModel.UserDataExport{
+		GeneratedAt: time.Now().UTC(),
+		UserID:      userID,
+	}
+
+	profile := map[string]interface{}{}
+	result := db.Model(&auth.User{}).Where("user_id = ?", userID).Limit(1).Find(&profile)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return nil, gorm.ErrRecordNotFound
+	}
+	export.Profile = withoutSecrets(profile)
+
+	details := map[string]interface{}{}
+	if err := db.Model(&auth.UserDetails{}).Where("user_id = ?", userID).Limit(1).Find(&details).Error; err != nil {
+		return nil, err
+	}
+	export.Details = withoutSecrets(details)
+
+	wallet := map[string]interface{}{}
+	result = db.Model(&models.Wallet{}).Where("client_id = ?", userID).Limit(1).Find(&wallet)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	if result.RowsAffected > 0 {
+		export.Wallet = wallet
+	}
+
+	lists := []struct {
+		dest  *[]map[string]interface{}
+		model interface{}
+		where string
+	}{
+		{&export.Bookings, &adminModel.Booking{}, "client_id = ? OR vendor_id = ?"},
+		{&export.Transactions, &clientModel.Transaction{}, "user_id = ?"},
+		{&export.Events, &clientModel.Event{}, "hosted_by = ?"},
+		{&export.CategoryRequests, &adminModel.CategoryRequest{}, "vendor_id = ?"},
+		{&export.VendorCategories, &models.VendorCategory{}, "vendor_id = ?"},
+		{&export.Verifications, &adminModel.VendorVerification{}, "vendor_id = ?"},
+		{&export.Documents, &adminModel.VerificationDocument{}, "verification_id IN (SELECT verification_id FROM vendor_verifications WHERE vendor_id = ?)"},
+	}
+
+	for _, list := range lists {
+		args := make([]interface{}, strings.Count(list.where, "?"))
+		for i := range args {
+			args[i] = userID
+		}
+
+		rows := []map[string]interface{}{}
+		if err := db.Model(list.model).Where(list.where, args...).Find(&rows).Error; err != nil {
+			return nil, err
+		}
+		*list.dest = rows
+	}
+
+	return export, nil
+}
+
+// EraseUserData anonymizes the personal data of a user while keeping the
+// rows that accounting depends on (bookings, transactions, wallets and admin
+// wallet entries) and the audit trail of admin actions (category requests,
+// decisions, reviews and role changes) intact. Where the user acted as an
+// admin, their email on those records is replaced by the erased address.
+// Events the user hosted are anonymized too. The account is also blocked
+// so it cannot be used again, and the erasure itself is recorded.
+func (r *AdminStorage) EraseUserData(ctx context.Context, erasure *adminModel.DataErasure) error {
+	userID := erasure.UserID.String()
+	erasedEmail := fmt.Sprintf("erased+%s@erased.invalid", userID)
+
+	return r.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var emails []string
+		if err := tx.Model(&auth.User{}).Where("user_id = ?", userID).Pluck("email", &emails).Error; err != nil {
+			return err
+		}
+		if len(emails) == 0 {
+			return gorm.ErrRecordNotFound
+		}
+
+		err := tx.Model(&auth.User{}).
+			Where("user_id = ?", userID).
+			Updates(map[string]interface{}{
+				"email":      erasedEmail,
+				"is_blocked": true,
+			}).Error
+		if err != nil {
+			return err
+		}
+
+		err = anonymizeTextColumns(tx, "user_details", "user_id", userID, map[string]interface{}{
+			"first_name": "Erased",
+			"last_name":  "User",
+		})
+		if err != nil {
+			return err
+		}
+
+		if err := anonymizeTextColumns(tx, "events", "hosted_by", userID, nil); err != nil {
+			return err
+		}
+
+		err = tx.Model(&adminModel.VerificationDocument{}).
+			Where("verification_id IN (SELECT verification_id FROM vendor_verifications WHERE vendor_id = ?)", userID).
+			Updates(map[string]interface{}{
+				"document_number": "",
+				"file_url":        "",
+			}).Error
+		if err != nil {
+			return err
+		}
+
+		if emails[0] != "" {
+			for _, actor := range adminActorColumns {
+				err := tx.Model(actor.model).
+					Where(actor.column+" = ?", emails[0]).
+					Update(actor.column, erasedEmail).Error
+				if err != nil {
+					return err
+				}
+			}
+		}
+
+		return tx.Create(erasure).Error
+	})
+}
+
+// anonymizeTextColumns blanks every text column of the user's rows in
+// table, found by keyColumn, using overrides where given. ID and status
+// columns are left alone so the owning service can still read the rows.
+// The column list is read from information_schema because the table is
+// owned by another service.
+func anonymizeTextColumns(tx *gorm.DB, table, keyColumn, userID string, overrides map[string]interface{}) error {
+	var columns []struct {
+		ColumnName string
+		IsNullable string
+	}
+
+	err := tx.Raw(`
+		SELECT column_name, is_nullable
+		FROM information_schema.columns
+		WHERE table_schema = current_schema()
+			AND table_name = ?
+			AND data_type IN ('text', 'character varying')
+			AND column_name <> ?
+			AND column_name <> 'status'
+			AND column_name NOT LIKE '%\_id'
+	`, table, keyColumn).Scan(&columns).Error
+	if err != nil {
+		return err
+	}
+
+	updates := make(map[string]interface{}, len(columns))
+	for _, column := range columns {
+		if value, ok := overrides[column.ColumnName]; ok {
+			updates[column.ColumnName] = value
+		} else if column.IsNullable == "YES" {
+			updates[column.ColumnName] = nil
+		} else {
+			updates[column.ColumnName] = ""
+		}
+	}
+
+	if len(updates) == 0 {
+		return nil
+	}
+
+	return tx.Table(table).Where(keyColumn+" = ?", userID).Updates(updates).Error
+}
+
+func withoutSecrets(row map[string]interface{}) map[string]interface{} {
+	for column := range row {
+		lower := strings.ToLower(column)
+		for _, marker := range secretColumnMarkers {
+			if strings.Contains(lower, marker) {
+				delete(row, column)
+				break
+			}
+		}
+	}
+
+	return row
+}
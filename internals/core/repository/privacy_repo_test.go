@@ -0,0 +1,49 @@
+package repository
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestWithoutSecrets(t *testing.T) {
+	tests := []struct {
+		name string
+		row  map[string]interface{}
+		want map[string]interface{}
+	}{
+		{
+			name: "no secrets",
+			row:  map[string]interface{}{"user_id": "u1", "email": "a@zyra.com"},
+			want: map[string]interface{}{"user_id": "u1", "email": "a@zyra.com"},
+		},
+		{
+			name: "secret columns",
+			row: map[string]interface{}{
+				"user_id":       "u1",
+				"password":      "hash",
+				"refresh_token": "t",
+				"otp_code":      "123456",
+				"client_secret": "s",
+			},
+			want: map[string]interface{}{"user_id": "u1"},
+		},
+		{
+			name: "mixed case column",
+			row:  map[string]interface{}{"name": "Asha", "ResetToken": "t"},
+			want: map[string]interface{}{"name": "Asha"},
+		},
+		{
+			name: "empty row",
+			row:  map[string]interface{}{},
+			want: map[string]interface{}{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := withoutSecrets(tt.row); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("withoutSecrets() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
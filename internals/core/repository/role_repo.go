@@ -0,0 +1,115 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	adminModel "github.com/AthulKrishna2501/zyra-admin-service/internals/core/models"
+	auth "github.com/AthulKrishna2501/zyra-auth-service/internals/core/models"
+	"github.com/AthulKrishna2501/zyra-vendor-service/internals/core/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var (
+	// ErrLastAdmin is returned when a role change would leave no admins.
+	ErrLastAdmin = errors.New("cannot remove the last admin")
+	// ErrRoleChanged is returned when the user's role was changed by
+	// someone else after it was read.
+	ErrRoleChanged = errors.New("role was changed concurrently")
+)
+
+func (r *AdminStorage) CountVendorCategories(ctx context.Context, vendorID string) (int64, error) {
+	var count int64
+	err := r.DB.WithContext(ctx).Model(&models.VendorCategory{}).Where("vendor_id = ?", vendorID).Count(&count).Error
+	return count, err
+}
+
+func (r *AdminStorage) CountOpenVendorBookings(ctx context.Context, vendorID string) (int64, error) {
+	var count int64
+	err := r.DB.WithContext(ctx).
+		Model(&adminModel.Booking{}).
+		Where("vendor_id = ? AND date >= CURRENT_DATE", vendorID).
+		Where("status NOT IN ?", []string{adminModel.BookingStatusCancelled, adminModel.BookingStatusCompleted}).
+		Count(&count).Error
+	return count, err
+}
+
+// ChangeUserRole updates users.role and writes the audit row in one
+// transaction. The update only applies if the user still has the previous
+// role, so two admins changing the same user cannot silently overwrite each
+// other. Demoting an admin locks every admin row first, so concurrent
+// demotions cannot both pass the last-admin check. When removeVendorData is
+// set the user's category memberships and pending category requests are
+// dropped as well, and the counts are recorded on change.
+func (r *AdminStorage) ChangeUserRole(ctx context.Context, change *adminModel.RoleChange, removeVendorData bool) error {
+	return r.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if change.PreviousRole == adminModel.RoleAdmin {
+			var admins []string
+			err := tx.Model(&auth.User{}).
+				Clauses(clause.Locking{Strength: "UPDATE"}).
+				Where("role = ?", adminModel.RoleAdmin).
+				Pluck("user_id", &admins).Error
+			if err != nil {
+				return err
+			}
+			if len(admins) <= 1 {
+				return ErrLastAdmin
+			}
+		}
+
+		result := tx.Model(&auth.User{}).
+			Where("user_id = ? AND role = ?", change.UserID, change.PreviousRole).
+			Update("role", change.NewRole)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return fmt.Errorf("%w: user %s no longer has role %s", ErrRoleChanged, change.UserID, change.PreviousRole)
+		}
+
+		if removeVendorData {
+			result := tx.Where("vendor_id = ?", change.UserID).Delete(&models.VendorCategory{})
+			if result.Error != nil {
+				return result.Error
+			}
+			change.RemovedCategories = result.RowsAffected
+
+			if err := tx.Where("vendor_id = ?", change.UserID).Delete(&adminModel.HiddenVendorCategory{}).Error; err != nil {
+				return err
+			}
+
+			result = tx.Where("vendor_id = ? AND status = ?", change.UserID, adminModel.CategoryRequestPending).
+				Delete(&adminModel.CategoryRequest{})
+			if result.Error != nil {
+				return result.Error
+			}
+			change.DroppedRequests = result.RowsAffected
+		}
+
+		return tx.Create(change).Error
+	})
+}
+
+func (r *AdminStorage) ListRoleChanges(ctx context.Context, userID string, limit, offset int) ([]adminModel.RoleChange, int64, error) {
+	var changes []adminModel.RoleChange
+	var total int64
+
+	query := r.DB.WithContext(ctx).Model(&adminModel.RoleChange{})
+	if userID != "" {
+		query = query.Where("user_id = ?", userID)
+	}
+	query = query.Session(&gorm.Session{})
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	err := query.Order("created_at DESC").Limit(limit).Offset(offset).Find(&changes).Error
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return changes, total, nil
+}
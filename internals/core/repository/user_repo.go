@@ -0,0 +1,304 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	adminModel "github.com/AthulKrishna2501/zyra-admin-service/internals/core/models"
+	auth "github.com/AthulKrishna2501/zyra-auth-service/internals/core/models"
+	clientModel "github.com/AthulKrishna2501/zyra-client-service/internals/core/models"
+	"github.com/AthulKrishna2501/zyra-vendor-service/internals/core/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+func (r *AdminStorage) FindUserIDs(ctx context.Context, filter adminModel.UserFilter) ([]string, error) {
+	var userIDs []string
+
+	query := r.DB.WithContext(ctx).Model(&auth.User{}).Where("role <> ?", adminModel.RoleAdmin)
+
+	if filter.Role != "" {
+		query = query.Where("role = ?", filter.Role)
+	}
+	if filter.Email != "" {
+		query = query.Where("email ILIKE ?", "%"+filter.Email+"%")
+	}
+	if filter.IsBlocked != nil {
+		query = query.Where("is_blocked = ?", *filter.IsBlocked)
+	}
+	if !filter.CreatedAfter.IsZero() {
+		query = query.Where("created_at >= ?", filter.CreatedAfter)
+	}
+	if !filter.CreatedBefore.IsZero() {
+		query = query.Where("created_at < ?", filter.CreatedBefore)
+	}
+
+	if err := query.Pluck("user_id", &userIDs).Error; err != nil {
+		return nil, err
+	}
+
+	return userIDs, nil
+}
+
+// SetUsersBlocked flips is_blocked for the given users in a single statement
+// and returns the users that were updated with the value each had before.
+// Admin accounts are never touched.
+func (r *AdminStorage) SetUsersBlocked(ctx context.Context, userIDs []string, blocked bool) ([]adminModel.BlockUpdate, error) {
+	var updated []adminModel.BlockUpdate
+	if len(userIDs) == 0 {
+		return updated, nil
+	}
+
+	err := r.DB.WithContext(ctx).
+		Raw(`
+			WITH previous AS (
+				SELECT user_id, is_blocked FROM users
+				WHERE user_id IN ? AND role <> 'admin'
+				FOR UPDATE
+			)
+			UPDATE users SET is_blocked = ?
+			FROM previous
+			WHERE users.user_id = previous.user_id
+			RETURNING users.user_id, previous.is_blocked AS was_blocked
+		`, userIDs, blocked).
+		Scan(&updated).Error
+
+	if err != nil {
+		return nil, err
+	}
+
+	return updated, nil
+}
+
+func (r *AdminStorage) GetUserRole(ctx context.Context, userID string) (string, error) {
+	var role string
+	result := r.DB.WithContext(ctx).
+		Model(&auth.User{}).
+		Select("role").
+		Where("user_id = ?", userID).
+		Scan(&role)
+
+	if result.Error != nil {
+		return "", result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		return "", fmt.Errorf("user_id %s: %w", userID, gorm.ErrRecordNotFound)
+	}
+
+	return role, nil
+}
+
+// GetUserStatus returns users.status, the approval state vendors had before
+// document verification existed.
+func (r *AdminStorage) GetUserStatus(ctx context.Context, userID string) (string, error) {
+	var userStatus string
+	result := r.DB.WithContext(ctx).
+		Model(&auth.User{}).
+		Select("status").
+		Where("user_id = ?", userID).
+		Scan(&userStatus)
+
+	if result.Error != nil {
+		return "", result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		return "", gorm.ErrRecordNotFound
+	}
+
+	return userStatus, nil
+}
+
+// HideVendorCategories moves the vendor's category memberships into
+// hidden_vendor_categories so they disappear from category listings while
+// the vendor is blocked. RestoreVendorCategories puts them back.
+func (r *AdminStorage) HideVendorCategories(ctx context.Context, vendorID, reason string) (int64, error) {
+	var hidden int64
+
+	err := r.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var memberships []models.VendorCategory
+		if err := tx.Where("vendor_id = ?", vendorID).Find(&memberships).Error; err != nil {
+			return err
+		}
+
+		for _, m := range memberships {
+			row := adminModel.HiddenVendorCategory{
+				VendorID:   m.VendorID,
+				CategoryID: m.CategoryID,
+				Reason:     reason,
+			}
+			if err := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&row).Error; err != nil {
+				return err
+			}
+		}
+
+		result := tx.Where("vendor_id = ?", vendorID).Delete(&models.VendorCategory{})
+		if result.Error != nil {
+			return result.Error
+		}
+
+		hidden = result.RowsAffected
+		return nil
+	})
+
+	if err != nil {
+		return 0, err
+	}
+
+	return hidden, nil
+}
+
+func (r *AdminStorage) RestoreVendorCategories(ctx context.Context, vendorID string) (int64, error) {
+	var restored int64
+
+	err := r.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var hidden []adminModel.HiddenVendorCategory
+		if err := tx.Where("vendor_id = ?", vendorID).Find(&hidden).Error; err != nil {
+			return err
+		}
+
+		for _, h := range hidden {
+			membership := models.VendorCategory{
+				VendorID:   h.VendorID,
+				CategoryID: h.CategoryID,
+			}
+			// The vendor may have been re-added to the category while
+			// blocked; that membership is kept as is.
+			result := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&membership)
+			if result.Error != nil {
+				return result.Error
+			}
+			restored += result.RowsAffected
+		}
+
+		return tx.Where("vendor_id = ?", vendorID).Delete(&adminModel.HiddenVendorCategory{}).Error
+	})
+
+	if err != nil {
+		return 0, err
+	}
+
+	return restored, nil
+}
+
+// CancelUpcomingVendorBookings cancels every booking of the vendor dated
+// today or later that is not already cancelled or completed, and refunds the
+// booking price from the admin wallet to the client's wallet for bookings
+// whose payment status is paid. Everything runs in one transaction so a failed refund
+// leaves no booking cancelled.
+func (r *AdminStorage) CancelUpcomingVendorBookings(ctx context.Context, vendorID, adminEmail string) ([]adminModel.Booking, error) {
+	var cancelled []adminModel.Booking
+
+	err := r.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		txRepo := &AdminStorage{DB: tx}
+
+		var err error
+		cancelled, err = txRepo.cancelUpcomingBookings(ctx, adminEmail, func(db *gorm.DB) *gorm.DB {
+			return db.Where("vendor_id = ?", vendorID)
+		})
+		return err
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return cancelled, nil
+}
+
+// cancelUpcomingBookings cancels the open bookings from today on that match
+// scope and refunds the ones whose payment status is paid. It must run
+// inside a transaction.
+func (r *AdminStorage) cancelUpcomingBookings(ctx context.Context, adminEmail string, scope func(*gorm.DB) *gorm.DB) ([]adminModel.Booking, error) {
+	var cancelled []adminModel.Booking
+
+	var bookings []adminModel.Booking
+	err := r.DB.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Scopes(scope).
+		Where("date >= CURRENT_DATE").
+		Where("status NOT IN ?", []string{adminModel.BookingStatusCancelled, adminModel.BookingStatusCompleted}).
+		Find(&bookings).Error
+	if err != nil {
+		return nil, err
+	}
+
+	for _, booking := range bookings {
+		updates := map[string]interface{}{"status": adminModel.BookingStatusCancelled}
+		paid := booking.PaymentStatus == adminModel.PaymentStatusPaid
+		if paid {
+			updates["payment_status"] = adminModel.PaymentStatusRefunded
+		}
+
+		if err := r.DB.Model(&adminModel.Booking{}).
+			Where("id = ?", booking.ID).
+			Updates(updates).Error; err != nil {
+			return nil, err
+		}
+
+		if paid {
+			if err := r.refundBooking(ctx, booking, adminEmail); err != nil {
+				return nil, err
+			}
+			booking.Refunded = true
+			booking.PaymentStatus = adminModel.PaymentStatusRefunded
+		}
+
+		booking.Status = adminModel.BookingStatusCancelled
+		cancelled = append(cancelled, booking)
+	}
+
+	return cancelled, nil
+}
+
+func (r *AdminStorage) refundBooking(ctx context.Context, booking adminModel.Booking, adminEmail string) error {
+	amount := float64(booking.Price)
+
+	if err := r.debitAdminWallet(ctx, amount, adminEmail); err != nil {
+		return err
+	}
+
+	err := r.CreateAdminWalletTransaction(ctx, &adminModel.AdminWalletTransaction{
+		Date:   time.Now(),
+		Type:   adminModel.TransactionTypeBookingRefund,
+		Amount: amount,
+		Status: "succeeded",
+	})
+	if err != nil {
+		return err
+	}
+
+	if err := r.CreditAmountToClientWallet(ctx, amount, booking.ClientID.String()); err != nil {
+		return err
+	}
+
+	return r.CreateTransaction(ctx, &clientModel.Transaction{
+		UserID:        booking.ClientID,
+		Purpose:       adminModel.TransactionTypeBookingRefund,
+		AmountPaid:    booking.Price,
+		PaymentMethod: "wallet",
+		DateOfPayment: time.Now(),
+		PaymentStatus: "refunded",
+	})
+}
+
+// debitAdminWallet takes amount out of the admin wallet and adds it to the
+// wallet's total withdrawals.
+func (r *AdminStorage) debitAdminWallet(ctx context.Context, amount float64, adminEmail string) error {
+	result := r.DB.WithContext(ctx).
+		Model(&adminModel.AdminWallet{}).
+		Where("email = ?", adminEmail).
+		Updates(map[string]interface{}{
+			"balance":           gorm.Expr("balance - ?", amount),
+			"total_withdrawals": gorm.Expr("total_withdrawals + ?", amount),
+		})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("admin wallet %s not found", adminEmail)
+	}
+
+	return nil
+}
@@ -0,0 +1,133 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	adminModel "github.com/AthulKrishna2501/zyra-admin-service/internals/core/models"
+	"github.com/AthulKrishna2501/zyra-vendor-service/internals/core/models"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var (
+	ErrNotCategoryMember = errors.New("vendor is not a member of this category")
+	// ErrOpenBookings is returned when a revocation with the refuse policy
+	// finds open bookings; their number is set on the revocation.
+	ErrOpenBookings = errors.New("vendor has open bookings in this category")
+)
+
+// RevokeVendorCategory removes the vendor from the category, including a
+// membership hidden by a block, and records the revocation. The vendor's
+// upcoming open bookings in the category are locked and counted in the same
+// transaction, so none can slip past the booking policy: with refuse any
+// open booking aborts the revocation, with cancel they are cancelled and
+// refunded, and with keep they are only counted.
+func (r *AdminStorage) RevokeVendorCategory(ctx context.Context, revocation *adminModel.CategoryRevocation, categoryName, adminEmail string) ([]adminModel.Booking, error) {
+	var cancelled []adminModel.Booking
+
+	err := r.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		removed := tx.Where("vendor_id = ? AND category_id = ?", revocation.VendorID, revocation.CategoryID).
+			Delete(&models.VendorCategory{})
+		if removed.Error != nil {
+			return removed.Error
+		}
+
+		unhidden := tx.Where("vendor_id = ? AND category_id = ?", revocation.VendorID, revocation.CategoryID).
+			Delete(&adminModel.HiddenVendorCategory{})
+		if unhidden.Error != nil {
+			return unhidden.Error
+		}
+
+		if removed.RowsAffected == 0 && unhidden.RowsAffected == 0 {
+			return ErrNotCategoryMember
+		}
+
+		scope := vendorCategoryBookings(revocation.VendorID.String(), categoryName)
+
+		var open []uuid.UUID
+		err := tx.Model(&adminModel.Booking{}).
+			Clauses(clause.Locking{Strength: "UPDATE"}).
+			Scopes(scope).
+			Where("date >= CURRENT_DATE").
+			Where("status NOT IN ?", []string{adminModel.BookingStatusCancelled, adminModel.BookingStatusCompleted}).
+			Pluck("id", &open).Error
+		if err != nil {
+			return err
+		}
+		revocation.BookingsAffected = int64(len(open))
+
+		switch revocation.BookingPolicy {
+		case adminModel.RevokeBookingsRefuse:
+			if len(open) > 0 {
+				return ErrOpenBookings
+			}
+		case adminModel.RevokeBookingsCancel:
+			txRepo := &AdminStorage{DB: tx}
+			cancelled, err = txRepo.cancelUpcomingBookings(ctx, adminEmail, scope)
+			if err != nil {
+				return err
+			}
+			revocation.BookingsAffected = int64(len(cancelled))
+		}
+
+		return tx.Create(revocation).Error
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return cancelled, nil
+}
+
+// GetVendorCategoryMemberships lists the categories a vendor is listed in,
+// those hidden while the vendor is blocked, and those revoked from them.
+func (r *AdminStorage) GetVendorCategoryMemberships(ctx context.Context, vendorID string) ([]adminModel.VendorCategoryMembership, error) {
+	var memberships []adminModel.VendorCategoryMembership
+
+	err := r.DB.WithContext(ctx).
+		Raw(`
+			SELECT c.category_id, c.category_name, ? AS status, '' AS reason,
+				(SELECT MAX(cr.decided_at) FROM category_requests cr
+					WHERE cr.vendor_id = vc.vendor_id AND cr.category_id = vc.category_id AND cr.status = ?) AS since
+			FROM vendor_categories vc
+			JOIN categories c ON c.category_id = vc.category_id
+			WHERE vc.vendor_id = ?
+			UNION ALL
+			SELECT c.category_id, c.category_name, ?, h.reason, h.created_at
+			FROM hidden_vendor_categories h
+			JOIN categories c ON c.category_id = h.category_id
+			WHERE h.vendor_id = ?
+			UNION ALL
+			SELECT * FROM (
+				SELECT DISTINCT ON (rv.category_id) c.category_id, c.category_name, ?, rv.reason, rv.created_at
+				FROM category_revocations rv
+				JOIN categories c ON c.category_id = rv.category_id
+				WHERE rv.vendor_id = ?
+					AND NOT EXISTS (SELECT 1 FROM vendor_categories vc WHERE vc.vendor_id = rv.vendor_id AND vc.category_id = rv.category_id)
+					AND NOT EXISTS (SELECT 1 FROM hidden_vendor_categories h WHERE h.vendor_id = rv.vendor_id AND h.category_id = rv.category_id)
+				ORDER BY rv.category_id, rv.created_at DESC
+			) revoked
+			ORDER BY category_name
+		`,
+			adminModel.MembershipActive, adminModel.CategoryRequestApproved, vendorID,
+			adminModel.MembershipHidden, vendorID,
+			adminModel.MembershipRevoked, vendorID,
+		).Scan(&memberships).Error
+
+	if err != nil {
+		return nil, err
+	}
+
+	return memberships, nil
+}
+
+// vendorCategoryBookings scopes bookings to a vendor and a category. Bookings
+// are tied to a category through their service name only.
+func vendorCategoryBookings(vendorID, categoryName string) func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		return db.Where("vendor_id = ? AND LOWER(TRIM(service)) = LOWER(?)", vendorID, categoryName)
+	}
+}
@@ -0,0 +1,136 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	adminModel "github.com/AthulKrishna2501/zyra-admin-service/internals/core/models"
+	auth "github.com/AthulKrishna2501/zyra-auth-service/internals/core/models"
+	"gorm.io/gorm"
+)
+
+// ErrVerificationDecided is returned when a verification was closed by
+// another reviewer between being read and being decided.
+var ErrVerificationDecided = errors.New("verification has already been decided")
+
+func (r *AdminStorage) CreateVendorVerification(ctx context.Context, verification *adminModel.VendorVerification) error {
+	return r.DB.WithContext(ctx).Create(verification).Error
+}
+
+func (r *AdminStorage) GetVendorVerification(ctx context.Context, verificationID string) (*adminModel.VendorVerification, error) {
+	var verification adminModel.VendorVerification
+
+	err := r.DB.WithContext(ctx).
+		Preload("Documents").
+		Preload("Checks", func(db *gorm.DB) *gorm.DB {
+			return db.Order("created_at")
+		}).
+		Where("verification_id = ?", verificationID).
+		First(&verification).Error
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &verification, nil
+}
+
+func (r *AdminStorage) GetLatestVendorVerification(ctx context.Context, vendorID string) (*adminModel.VendorVerification, error) {
+	var verification adminModel.VendorVerification
+
+	err := r.DB.WithContext(ctx).
+		Where("vendor_id = ?", vendorID).
+		Order("created_at DESC").
+		First(&verification).Error
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &verification, nil
+}
+
+func (r *AdminStorage) ListVerificationQueue(ctx context.Context, statuses []string, limit, offset int) ([]adminModel.VerificationQueueItem, int64, error) {
+	var items []adminModel.VerificationQueueItem
+	var total int64
+
+	base := r.DB.WithContext(ctx).
+		Model(&adminModel.VendorVerification{}).
+		Where("vendor_verifications.status IN ?", statuses).
+		Session(&gorm.Session{})
+
+	if err := base.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	err := base.
+		Select(`
+			vendor_verifications.verification_id,
+			vendor_verifications.vendor_id,
+			vendor_verifications.status,
+			vendor_verifications.created_at,
+			users.email,
+			CONCAT(user_details.first_name, ' ', user_details.last_name) AS vendor_name,
+			(SELECT COUNT(*) FROM verification_documents d WHERE d.verification_id = vendor_verifications.verification_id) AS document_count,
+			(SELECT COUNT(*) FROM verification_checks c WHERE c.verification_id = vendor_verifications.verification_id) AS check_count
+		`).
+		Joins("JOIN users ON users.user_id = vendor_verifications.vendor_id").
+		Joins("LEFT JOIN user_details ON user_details.user_id = vendor_verifications.vendor_id").
+		Order("vendor_verifications.created_at ASC").
+		Limit(limit).
+		Offset(offset).
+		Scan(&items).Error
+
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return items, total, nil
+}
+
+// CreateVerificationCheck records a check and moves a pending verification
+// into review, so the queue shows which submissions somebody has picked up.
+func (r *AdminStorage) CreateVerificationCheck(ctx context.Context, check *adminModel.VerificationCheck) error {
+	return r.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(check).Error; err != nil {
+			return err
+		}
+
+		return tx.Model(&adminModel.VendorVerification{}).
+			Where("verification_id = ? AND status = ?", check.VerificationID, adminModel.VerificationStatusPending).
+			Update("status", adminModel.VerificationStatusInReview).Error
+	})
+}
+
+// DecideVendorVerification closes an open verification and mirrors the
+// decision onto users.status, which the other services read as the vendor's
+// approval state.
+func (r *AdminStorage) DecideVendorVerification(ctx context.Context, verificationID, status, reason, reviewedBy string) error {
+	return r.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var verification adminModel.VendorVerification
+		if err := tx.Where("verification_id = ?", verificationID).First(&verification).Error; err != nil {
+			return err
+		}
+
+		result := tx.Model(&adminModel.VendorVerification{}).
+			Where("verification_id = ?", verificationID).
+			Where("status IN ?", []string{adminModel.VerificationStatusPending, adminModel.VerificationStatusInReview}).
+			Updates(map[string]interface{}{
+				"status":      status,
+				"reason":      reason,
+				"reviewed_by": reviewedBy,
+				"reviewed_at": time.Now(),
+			})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return ErrVerificationDecided
+		}
+
+		return tx.Model(&auth.User{}).
+			Where("user_id = ?", verification.VendorID).
+			Update("status", status).Error
+	})
+}
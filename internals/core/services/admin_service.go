@@ -2,11 +2,14 @@ package services
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"strings"
 	"time"
 
 	pb "github.com/AthulKrishna2501/proto-repo/admin"
 	"github.com/AthulKrishna2501/zyra-admin-service/internals/app/config"
+	"github.com/AthulKrishna2501/zyra-admin-service/internals/core/events"
 	adminModel "github.com/AthulKrishna2501/zyra-admin-service/internals/core/models"
 	"github.com/AthulKrishna2501/zyra-admin-service/internals/core/repository"
 	"github.com/AthulKrishna2501/zyra-admin-service/internals/logger"
@@ -16,49 +19,56 @@ import (
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/timestamppb"
+	"gorm.io/gorm"
 )
 
+const adminWalletEmail = "admin@example.com"
+
 type AdminService struct {
 	pb.UnimplementedAdminServiceServer
-	AdminRepo   repository.AdminRepository
-	redisClient *redis.Client
-	log         logger.Logger
+	AdminRepo       repository.AdminRepository
+	redisClient     *redis.Client
+	log             logger.Logger
+	revocationHooks []CategoryRevocationHook
+	feed            *events.Bus
 }
 
-func NewAdminService(AdminRepo repository.AdminRepository, logger logger.Logger) *AdminService {
-	return &AdminService{AdminRepo: AdminRepo, redisClient: config.RedisClient, log: logger}
+func NewAdminService(AdminRepo repository.AdminRepository, feed *events.Bus, logger logger.Logger) *AdminService {
+	return &AdminService{AdminRepo: AdminRepo, redisClient: config.RedisClient, log: logger, feed: feed}
 }
 
 func (s *AdminService) ApproveRejectCategory(ctx context.Context, req *pb.ApproveRejectCategoryRequest) (*pb.ApproveRejectCategoryResponse, error) {
-	s.log.Info("Admin Service: Received gRPC request - VendorID=%s, CategoryID=%s, Status=%s",
-		req.VendorId, req.CategoryId, req.Status)
+	s.log.Info("Admin Service: Received gRPC request - RequestID=%s, VendorID=%s, CategoryID=%s, Status=%s",
+		req.RequestId, req.VendorId, req.CategoryId, req.Status)
 
-	if req.VendorId == "" || req.CategoryId == "" || req.Status == "" {
-		return nil, status.Errorf(codes.InvalidArgument, "VendorID, CategoryID, and Status are required")
+	if req.Status == "" || (req.RequestId == "" && (req.VendorId == "" || req.CategoryId == "")) {
+		return nil, status.Errorf(codes.InvalidArgument, "Status and either RequestID or VendorID and CategoryID are required")
 	}
 
-	if req.Status != "approved" && req.Status != "rejected" {
-		return nil, status.Errorf(codes.InvalidArgument, "Invalid status. Allowed values: 'approved', 'rejected'")
+	decision := adminModel.CategoryDecision{
+		Status:     req.Status,
+		ReasonCode: req.ReasonCode,
+		Notes:      strings.TrimSpace(req.Notes),
+		DecidedBy:  req.DecidedBy,
 	}
-
-	if req.Status == "approved" {
-		if err := s.AdminRepo.AddVendorCategory(ctx, req.VendorId, req.CategoryId); err != nil {
-			return nil, status.Error(codes.Internal, err.Error())
-		}
+	if req.RequestId == "" {
+		decision = legacyCategoryDecision(decision)
+	}
+	if err := validateCategoryDecision(decision); err != nil {
+		return nil, err
 	}
 
-	err := s.AdminRepo.UpdateRequestStatus(ctx, req.VendorId, req.Status)
+	request, err := s.findCategoryRequest(ctx, req.RequestId, req.VendorId, req.CategoryId)
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "Failed to update status: %v", err)
+		return nil, err
 	}
 
-	err = s.AdminRepo.DeleteRequest(ctx, req.VendorId)
-	if err != nil {
-		return nil, status.Errorf(codes.Internal, "Failed to delete category request %v", err)
+	if err := s.applyCategoryDecision(ctx, request, decision); err != nil {
+		return nil, err
 	}
 
-	s.log.Info("Admin Service: Successfully updated category request - VendorID=%s, CategoryID=%s, Status=%s",
-		req.VendorId, req.CategoryId, req.Status)
+	s.log.Info("Admin Service: Successfully updated category request - RequestID=%s, VendorID=%s, CategoryID=%s, Status=%s",
+		request.RequestID, request.VendorID, request.CategoryID, req.Status)
 
 	return &pb.ApproveRejectCategoryResponse{
 		Message: fmt.Sprintf("Category request has been %s", req.Status),
@@ -66,18 +76,56 @@ func (s *AdminService) ApproveRejectCategory(ctx context.Context, req *pb.Approv
 
 }
 
+// applyCategoryDecision checks that the request can still be decided and
+// records the decision. Approval also requires the category to exist and the
+// vendor to be verified. The repository re-checks the status and assignment
+// under a row lock, so a request assigned to another admin is refused even
+// if it was reassigned after it was read here.
+func (s *AdminService) applyCategoryDecision(ctx context.Context, request *adminModel.CategoryRequest, decision adminModel.CategoryDecision) error {
+	if request.Status != adminModel.CategoryRequestPending {
+		return status.Errorf(codes.FailedPrecondition, "Category request %s has already been %s", request.RequestID, request.Status)
+	}
+
+	if decision.Status == adminModel.CategoryRequestApproved {
+		if _, err := s.AdminRepo.GetCategory(ctx, request.CategoryID.String()); err != nil {
+			return categoryError(err, request.CategoryID.String())
+		}
+
+		if err := s.requireVerifiedVendor(ctx, request.VendorID.String()); err != nil {
+			return err
+		}
+	}
+
+	err := s.AdminRepo.DecideCategoryRequest(ctx, request.RequestID.String(), decision)
+	switch {
+	case errors.Is(err, repository.ErrRequestAlreadyDecided):
+		return status.Errorf(codes.FailedPrecondition, "Category request %s has already been decided", request.RequestID)
+	case errors.Is(err, repository.ErrRequestAssigned):
+		return status.Errorf(codes.FailedPrecondition, "Category request %s is assigned to another admin", request.RequestID)
+	case err != nil:
+		return status.Errorf(codes.Internal, "Failed to update status: %v", err)
+	}
+
+	return nil
+}
+
 func (s *AdminService) BlockUser(ctx context.Context, req *pb.BlockUnblockUserRequest) (*pb.BlockUnblockUserResponse, error) {
 	if req.UserId == "" {
 		return nil, status.Errorf(codes.InvalidArgument, "User ID cannot be empty")
 	}
 
-	err := s.redisClient.SAdd(ctx, "blocked_users", req.UserId).Err()
-	if err != nil {
-		return nil, status.Errorf(codes.Internal, "Failed to block user: %v", err)
+	if err := s.setUserBlocked(ctx, req.UserId, true); err != nil {
+		return nil, err
+	}
+
+	var steps []adminModel.CascadeStepResult
+	if req.Cascade != nil {
+		steps = s.runBlockCascade(ctx, req.UserId, req.Cascade)
 	}
 
 	return &pb.BlockUnblockUserResponse{
-		Message: fmt.Sprintf("User %s has been blocked", req.UserId),
+		Message:      fmt.Sprintf("User %s has been blocked", req.UserId),
+		CascadeSteps: toPbCascadeSteps(steps),
 	}, nil
 }
 
@@ -86,13 +134,15 @@ func (s *AdminService) UnblockUser(ctx context.Context, req *pb.BlockUnblockUser
 		return nil, status.Errorf(codes.InvalidArgument, "User ID cannot be empty")
 	}
 
-	err := s.redisClient.SRem(ctx, "blocked_users", req.UserId).Err()
-	if err != nil {
-		return nil, status.Errorf(codes.Internal, "Failed to unblock user: %v", err)
+	if err := s.setUserBlocked(ctx, req.UserId, false); err != nil {
+		return nil, err
 	}
 
+	steps := s.runUnblockCascade(ctx, req.UserId)
+
 	return &pb.BlockUnblockUserResponse{
-		Message: fmt.Sprintf("User %s has been unblocked", req.UserId),
+		Message:      fmt.Sprintf("User %s has been unblocked", req.UserId),
+		CascadeSteps: toPbCascadeSteps(steps),
 	}, nil
 }
 
@@ -118,32 +168,105 @@ func (s *AdminService) ListUsers(ctx context.Context, req *pb.ListUsersRequest)
 }
 
 func (s *AdminService) ViewRequests(ctx context.Context, req *pb.ViewRequestsReq) (*pb.ViewRequestsResponse, error) {
-	requests, err := s.AdminRepo.GetRequests(ctx)
+	requestStatus := req.Status
+	switch requestStatus {
+	case "":
+		requestStatus = adminModel.CategoryRequestPending
+	case "all":
+		requestStatus = ""
+	case adminModel.CategoryRequestPending, adminModel.CategoryRequestApproved, adminModel.CategoryRequestRejected:
+	default:
+		return nil, status.Errorf(codes.InvalidArgument, "Invalid status. Allowed values: 'pending', 'approved', 'rejected', 'all'")
+	}
+
+	filter, err := categoryRequestFilter(req, requestStatus)
 	if err != nil {
 		return nil, err
 	}
 
-	var pbRequests []*pb.CategoryRequest
-	for _, r := range requests {
-		pbRequests = append(pbRequests, &pb.CategoryRequest{
-			VendorId:   r.VendorID.String(),
-			CategoryId: r.CategoryID.String(),
-			Name:       r.CategoryName,
-			VendorName: r.VendorName,
-			Date:       r.CreatedAt.String(),
-		})
+	requests, total, err := s.AdminRepo.GetRequests(ctx, filter)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Failed to fetch category requests: %v", err)
+	}
+
+	buckets, err := s.AdminRepo.CountCategoryRequestsByAge(ctx, filter, categoryRequestDueSoon, categoryRequestOverdue)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Failed to count category requests by age: %v", err)
+	}
 
+	pbRequests, err := s.toPbCategoryRequests(ctx, requests, req.IncludeHistory)
+	if err != nil {
+		return nil, err
+	}
+
+	var ageBuckets []*pb.AgeBucket
+	for _, b := range buckets {
+		ageBuckets = append(ageBuckets, &pb.AgeBucket{
+			Bucket: b.Bucket,
+			Count:  int32(b.Count),
+		})
 	}
 
 	return &pb.ViewRequestsResponse{
-		Requests: pbRequests,
+		Requests:   pbRequests,
+		Total:      int32(total),
+		AgeBuckets: ageBuckets,
 	}, nil
 }
 
+// findCategoryRequest looks a request up by its ID, falling back to the
+// oldest pending request for the vendor and category pair for callers that
+// do not send a request ID yet.
+func (s *AdminService) findCategoryRequest(ctx context.Context, requestID, vendorID, categoryID string) (*adminModel.CategoryRequest, error) {
+	var request *adminModel.CategoryRequest
+	var err error
+
+	if requestID != "" {
+		if _, parseErr := uuid.Parse(requestID); parseErr != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "Invalid request ID: %v", parseErr)
+		}
+		request, err = s.AdminRepo.GetCategoryRequest(ctx, requestID)
+	} else {
+		request, err = s.AdminRepo.FindPendingCategoryRequest(ctx, vendorID, categoryID)
+	}
+
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, status.Errorf(codes.NotFound, "Category request not found")
+	} else if err != nil {
+		return nil, status.Errorf(codes.Internal, "Failed to fetch category request: %v", err)
+	}
+
+	return request, nil
+}
+
 func (s *AdminService) AddCategory(ctx context.Context, req *pb.AddCategoryRequest) (*pb.AddCategoryResponse, error) {
-	err := s.AdminRepo.CreateCategory(ctx, req.CategoryName)
+	name := adminModel.NormalizeCategoryName(req.CategoryName)
+	if name == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "Category name cannot be empty")
+	}
+
+	var parentID *uuid.UUID
+	if req.ParentId != "" {
+		parsed, err := uuid.Parse(req.ParentId)
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "Invalid parent category ID: %v", err)
+		}
+		parentID = &parsed
+	}
+
+	slug, err := s.uniqueCategorySlug(ctx, name)
 	if err != nil {
-		return nil, err
+		return nil, status.Errorf(codes.Internal, "Failed to generate category slug: %v", err)
+	}
+
+	err = s.AdminRepo.CreateCategory(ctx, &adminModel.Category{
+		CategoryName: name,
+		ParentID:     parentID,
+		Slug:         slug,
+		IsActive:     true,
+	})
+	if err != nil {
+		return nil, categoryError(err, req.ParentId)
 	}
 
 	return &pb.AddCategoryResponse{
@@ -152,7 +275,11 @@ func (s *AdminService) AddCategory(ctx context.Context, req *pb.AddCategoryReque
 }
 
 func (s *AdminService) AdminDashBoard(ctx context.Context, req *pb.AdminDashBoardRequest) (*pb.AdminDashBoardResponse, error) {
-	stats, err := s.AdminRepo.GetAdminDashboard(ctx)
+	if hasDashboardFilters(req) {
+		return s.filteredDashboard(ctx, req)
+	}
+
+	stats, err := s.cachedDashboardStats(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -201,21 +328,13 @@ func (s *AdminService) GetAdminWalletTransactions(ctx context.Context, req *pb.G
 }
 
 func (s *AdminService) ListCategory(ctx context.Context, req *pb.ListCategoryRequest) (*pb.ListCategoryResponse, error) {
-	categories, err := s.AdminRepo.ListCategories(ctx)
+	categories, err := s.AdminRepo.ListCategories(ctx, req.ActiveOnly)
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, "Failed to fetch categories: %v", err)
 	}
 
-	var categoryResponses []*pb.Category
-	for _, cat := range categories {
-		categoryResponses = append(categoryResponses, &pb.Category{
-			CategoryId:   cat.CategoryID.String(),
-			CategoryName: cat.CategoryName,
-		})
-	}
-
 	return &pb.ListCategoryResponse{
-		Categories: categoryResponses,
+		Categories: buildCategoryList(categories, req.Tree),
 	}, nil
 }
 
@@ -288,6 +407,14 @@ func (s *AdminService) ApproveFundRelease(ctx context.Context, req *pb.ApproveFu
 			return nil, status.Errorf(codes.Internal, "failed to fetch userID %v", err)
 		}
 
+		onHold, err := s.AdminRepo.HasActivePayoutHold(ctx, userID)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to check payout hold %v", err)
+		}
+		if onHold {
+			return nil, status.Errorf(codes.FailedPrecondition, "payouts for user %s are on hold", userID)
+		}
+
 		userUUID, err := uuid.Parse(userID)
 		if err != nil {
 			return nil, status.Errorf(codes.InvalidArgument, "failed to parse user_id %v", err)
@@ -304,12 +431,12 @@ func (s *AdminService) ApproveFundRelease(ctx context.Context, req *pb.ApproveFu
 
 		newAdminWalletTransaction := &adminModel.AdminWalletTransaction{
 			Date:   time.Now(),
-			Type:   "Fund Release",
+			Type:   adminModel.TransactionTypeFundRelease,
 			Amount: details.Amount,
 			Status: "succeeded",
 		}
 
-		err = s.AdminRepo.DebitAmountFromAdminWallet(ctx, details.Amount, "admin@example.com")
+		err = s.AdminRepo.DebitAmountFromAdminWallet(ctx, details.Amount, adminWalletEmail)
 		if err != nil {
 			return nil, status.Errorf(codes.Internal, "failed to debit amount from admin wallet %v ", err)
 		}
@@ -0,0 +1,283 @@
+package services
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"time"
+
+	pb "github.com/AthulKrishna2501/proto-repo/admin"
+	adminModel "github.com/AthulKrishna2501/zyra-admin-service/internals/core/models"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const (
+	defaultAnalyticsPeriod = 30 * 24 * time.Hour
+
+	// A category is underserved when it has bookings in the period but
+	// fewer approved vendors than this.
+	defaultMinVendors = 3
+
+	// maxTimeSeriesBuckets keeps a daily series over several years from
+	// being requested by accident.
+	maxTimeSeriesBuckets = 400
+)
+
+func (s *AdminService) GetCategoryUsage(ctx context.Context, req *pb.GetCategoryUsageRequest) (*pb.GetCategoryUsageResponse, error) {
+	period, err := analyticsPeriod(req.From, req.To)
+	if err != nil {
+		return nil, err
+	}
+
+	minVendors := int64(req.MinVendors)
+	if minVendors <= 0 {
+		minVendors = defaultMinVendors
+	}
+
+	usage, err := s.AdminRepo.GetCategoryUsage(ctx, period)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Failed to fetch category usage: %v", err)
+	}
+
+	var categories []*pb.CategoryUsage
+	for _, u := range usage {
+		underserved := u.Bookings > 0 && u.ApprovedVendors < minVendors
+		if req.UnderservedOnly && !underserved {
+			continue
+		}
+
+		item := &pb.CategoryUsage{
+			CategoryId:        u.CategoryID.String(),
+			CategoryName:      u.CategoryName,
+			ApprovedVendors:   int32(u.ApprovedVendors),
+			PendingRequests:   int32(u.PendingRequests),
+			NewRequests:       int32(u.NewRequests),
+			Bookings:          int32(u.Bookings),
+			Revenue:           u.Revenue,
+			BookingsPerVendor: float64(u.Bookings) / float64(max(u.ApprovedVendors, 1)),
+			Underserved:       underserved,
+		}
+		if u.ParentID != nil {
+			item.ParentId = u.ParentID.String()
+		}
+
+		categories = append(categories, item)
+	}
+
+	// Underserved categories first, then the ones with the most demand per
+	// vendor, so the top of the list is where recruiting vendors pays off.
+	sort.SliceStable(categories, func(i, j int) bool {
+		if categories[i].Underserved != categories[j].Underserved {
+			return categories[i].Underserved
+		}
+		return categories[i].BookingsPerVendor > categories[j].BookingsPerVendor
+	})
+
+	return &pb.GetCategoryUsageResponse{
+		Categories: categories,
+		From:       timestamppb.New(period.From),
+		To:         timestamppb.New(period.To),
+	}, nil
+}
+
+func (s *AdminService) GetTimeSeriesAnalytics(ctx context.Context, req *pb.GetTimeSeriesAnalyticsRequest) (*pb.GetTimeSeriesAnalyticsResponse, error) {
+	period, err := analyticsPeriod(req.From, req.To)
+	if err != nil {
+		return nil, err
+	}
+
+	granularity := req.Granularity
+	var step time.Duration
+	switch granularity {
+	case "", adminModel.GranularityDay:
+		granularity = adminModel.GranularityDay
+		step = 24 * time.Hour
+	case adminModel.GranularityWeek:
+		step = 7 * 24 * time.Hour
+	case adminModel.GranularityMonth:
+		step = 28 * 24 * time.Hour
+	default:
+		return nil, status.Errorf(codes.InvalidArgument, "Invalid granularity. Allowed values: 'day', 'week', 'month'")
+	}
+
+	if period.To.Sub(period.From)/step > maxTimeSeriesBuckets {
+		return nil, status.Errorf(codes.InvalidArgument, "Range is too long for %s buckets, use a coarser granularity", granularity)
+	}
+
+	current, err := s.AdminRepo.GetTimeSeries(ctx, period, granularity)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Failed to fetch time series: %v", err)
+	}
+
+	previousPeriod := period.Previous()
+	previous, err := s.AdminRepo.GetTimeSeries(ctx, previousPeriod, granularity)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Failed to fetch previous period: %v", err)
+	}
+
+	currentTotals := sumTimeSeries(current)
+	previousTotals := sumTimeSeries(previous)
+
+	return &pb.GetTimeSeriesAnalyticsResponse{
+		Granularity:    granularity,
+		From:           timestamppb.New(period.From),
+		To:             timestamppb.New(period.To),
+		PreviousFrom:   timestamppb.New(previousPeriod.From),
+		PreviousTo:     timestamppb.New(previousPeriod.To),
+		Points:         toPbTimeSeries(current),
+		PreviousPoints: toPbTimeSeries(previous),
+		Comparison: []*pb.MetricComparison{
+			compareMetric("revenue", float64(currentTotals.Revenue), float64(previousTotals.Revenue)),
+			compareMetric("bookings", float64(currentTotals.Bookings), float64(previousTotals.Bookings)),
+			compareMetric("new_clients", float64(currentTotals.NewClients), float64(previousTotals.NewClients)),
+			compareMetric("new_vendors", float64(currentTotals.NewVendors), float64(previousTotals.NewVendors)),
+			compareMetric("wallet_inflow", currentTotals.WalletInflow, previousTotals.WalletInflow),
+			compareMetric("wallet_outflow", currentTotals.WalletOutflow, previousTotals.WalletOutflow),
+		},
+	}, nil
+}
+
+func (s *AdminService) GetBookingFunnel(ctx context.Context, req *pb.GetBookingFunnelRequest) (*pb.GetBookingFunnelResponse, error) {
+	period, err := analyticsPeriod(req.From, req.To)
+	if err != nil {
+		return nil, err
+	}
+
+	filter := adminModel.DashboardFilter{
+		From:    period.From,
+		To:      period.To,
+		Service: strings.TrimSpace(req.Service),
+	}
+
+	funnel, err := s.AdminRepo.GetBookingFunnel(ctx, filter)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Failed to fetch booking funnel: %v", err)
+	}
+
+	stages := funnel.Stages
+	resp := &pb.GetBookingFunnelResponse{
+		From: timestamppb.New(period.From),
+		To:   timestamppb.New(period.To),
+		Stages: []*pb.FunnelStage{
+			funnelStage("created", stages.Created, stages.Created, stages.Created, nil),
+			funnelStage("vendor_approved", stages.VendorApproved, stages.Created, stages.Created, stages.MedianVendorApprovalSeconds),
+			funnelStage("client_approved", stages.ClientApproved, stages.VendorApproved, stages.Created, stages.MedianClientApprovalSeconds),
+			funnelStage("fund_released", stages.FundReleased, stages.ClientApproved, stages.Created, stages.MedianFundReleaseSeconds),
+		},
+	}
+
+	for _, st := range funnel.Statuses {
+		resp.Statuses = append(resp.Statuses, &pb.BookingStatusCount{
+			Status:   st.Status,
+			Bookings: int32(st.Bookings),
+			Revenue:  st.Revenue,
+			Share:    rate(st.Bookings, stages.Created),
+		})
+	}
+
+	for _, st := range funnel.ApprovalStates {
+		resp.ApprovalStates = append(resp.ApprovalStates, &pb.BookingApprovalState{
+			IsVendorApproved: st.IsVendorApproved,
+			IsClientApproved: st.IsClientApproved,
+			IsFundReleased:   st.IsFundReleased,
+			Bookings:         int32(st.Bookings),
+		})
+	}
+
+	return resp, nil
+}
+
+// funnelStage reports a stage with its conversion from the previous stage
+// and from the top of the funnel. Rates are 0 when the base is empty.
+func funnelStage(name string, bookings, previous, created int64, medianSeconds *float64) *pb.FunnelStage {
+	stage := &pb.FunnelStage{
+		Name:           name,
+		Bookings:       int32(bookings),
+		ConversionRate: rate(bookings, previous),
+		OverallRate:    rate(bookings, created),
+	}
+	if medianSeconds != nil {
+		hours := *medianSeconds / 3600
+		stage.MedianHoursFromPrevious = &hours
+	}
+
+	return stage
+}
+
+func rate(part, whole int64) float64 {
+	if whole == 0 {
+		return 0
+	}
+
+	return float64(part) / float64(whole)
+}
+
+func sumTimeSeries(points []adminModel.TimeSeriesPoint) adminModel.TimeSeriesPoint {
+	var total adminModel.TimeSeriesPoint
+	for _, p := range points {
+		total.Bookings += p.Bookings
+		total.Revenue += p.Revenue
+		total.NewClients += p.NewClients
+		total.NewVendors += p.NewVendors
+		total.WalletInflow += p.WalletInflow
+		total.WalletOutflow += p.WalletOutflow
+	}
+
+	return total
+}
+
+func toPbTimeSeries(points []adminModel.TimeSeriesPoint) []*pb.TimeSeriesPoint {
+	var pbPoints []*pb.TimeSeriesPoint
+	for _, p := range points {
+		pbPoints = append(pbPoints, &pb.TimeSeriesPoint{
+			BucketStart:   timestamppb.New(p.Bucket),
+			Bookings:      int32(p.Bookings),
+			Revenue:       p.Revenue,
+			NewClients:    int32(p.NewClients),
+			NewVendors:    int32(p.NewVendors),
+			WalletInflow:  p.WalletInflow,
+			WalletOutflow: p.WalletOutflow,
+		})
+	}
+
+	return pbPoints
+}
+
+// compareMetric reports a metric against the previous period. The
+// percentage change is left unset when the previous value is zero.
+func compareMetric(name string, current, previous float64) *pb.MetricComparison {
+	comparison := &pb.MetricComparison{
+		Metric:   name,
+		Current:  current,
+		Previous: previous,
+		Change:   current - previous,
+	}
+	if previous != 0 {
+		pct := (current - previous) / previous * 100
+		comparison.ChangePercent = &pct
+	}
+
+	return comparison
+}
+
+// analyticsPeriod turns an optional from/to pair into a period, defaulting
+// to the 30 days up to now.
+func analyticsPeriod(from, to *timestamppb.Timestamp) (adminModel.Period, error) {
+	period := adminModel.Period{To: time.Now()}
+	if to != nil {
+		period.To = to.AsTime()
+	}
+
+	period.From = period.To.Add(-defaultAnalyticsPeriod)
+	if from != nil {
+		period.From = from.AsTime()
+	}
+
+	if !period.From.Before(period.To) {
+		return period, status.Errorf(codes.InvalidArgument, "From must be before To")
+	}
+
+	return period, nil
+}
@@ -0,0 +1,263 @@
+package services
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	pb "github.com/AthulKrishna2501/proto-repo/admin"
+	adminModel "github.com/AthulKrishna2501/zyra-admin-service/internals/core/models"
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func TestAnalyticsPeriod(t *testing.T) {
+	to := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+	from := time.Date(2025, 5, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		from, to *timestamppb.Timestamp
+		wantFrom time.Time
+		wantTo   time.Time
+		wantCode codes.Code
+	}{
+		{"both bounds", timestamppb.New(from), timestamppb.New(to), from, to, codes.OK},
+		{"to only defaults the start", nil, timestamppb.New(to), to.Add(-defaultAnalyticsPeriod), to, codes.OK},
+		{"from after to", timestamppb.New(to), timestamppb.New(from), to, from, codes.InvalidArgument},
+		{"empty range", timestamppb.New(to), timestamppb.New(to), to, to, codes.InvalidArgument},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			period, err := analyticsPeriod(tt.from, tt.to)
+			if got := status.Code(err); got != tt.wantCode {
+				t.Fatalf("code = %v, want %v", got, tt.wantCode)
+			}
+			if !period.From.Equal(tt.wantFrom) || !period.To.Equal(tt.wantTo) {
+				t.Errorf("period = %v - %v, want %v - %v", period.From, period.To, tt.wantFrom, tt.wantTo)
+			}
+		})
+	}
+}
+
+func TestCompareMetric(t *testing.T) {
+	tests := []struct {
+		name              string
+		current, previous float64
+		wantChange        float64
+		wantPercent       *float64
+	}{
+		{"growth", 150, 100, 50, ptr(50.0)},
+		{"decline", 50, 100, -50, ptr(-50.0)},
+		{"no previous", 10, 0, 10, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := compareMetric("bookings", tt.current, tt.previous)
+			if got.Change != tt.wantChange {
+				t.Errorf("change = %v, want %v", got.Change, tt.wantChange)
+			}
+			switch {
+			case tt.wantPercent == nil && got.ChangePercent != nil:
+				t.Errorf("change percent = %v, want nil", *got.ChangePercent)
+			case tt.wantPercent != nil && (got.ChangePercent == nil || *got.ChangePercent != *tt.wantPercent):
+				t.Errorf("change percent = %v, want %v", got.ChangePercent, *tt.wantPercent)
+			}
+		})
+	}
+}
+
+func TestFunnelStage(t *testing.T) {
+	tests := []struct {
+		name           string
+		bookings       int64
+		previous       int64
+		created        int64
+		median         *float64
+		wantConversion float64
+		wantOverall    float64
+		wantHours      *float64
+	}{
+		{"top of funnel", 40, 40, 40, nil, 1, 1, nil},
+		{"half converted", 20, 40, 40, ptr(7200.0), 0.5, 0.5, ptr(2.0)},
+		{"later stage", 5, 20, 40, ptr(1800.0), 0.25, 0.125, ptr(0.5)},
+		{"empty funnel", 0, 0, 0, nil, 0, 0, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stage := funnelStage("stage", tt.bookings, tt.previous, tt.created, tt.median)
+			if stage.ConversionRate != tt.wantConversion || stage.OverallRate != tt.wantOverall {
+				t.Errorf("rates = %v/%v, want %v/%v", stage.ConversionRate, stage.OverallRate, tt.wantConversion, tt.wantOverall)
+			}
+			switch {
+			case tt.wantHours == nil && stage.MedianHoursFromPrevious != nil:
+				t.Errorf("median hours = %v, want nil", *stage.MedianHoursFromPrevious)
+			case tt.wantHours != nil && (stage.MedianHoursFromPrevious == nil || *stage.MedianHoursFromPrevious != *tt.wantHours):
+				t.Errorf("median hours = %v, want %v", stage.MedianHoursFromPrevious, *tt.wantHours)
+			}
+		})
+	}
+}
+
+func ptr[T any](v T) *T {
+	return &v
+}
+
+func TestGetCategoryUsage(t *testing.T) {
+	usage := []adminModel.CategoryUsage{
+		{CategoryID: uuid.New(), CategoryName: "Catering", ApprovedVendors: 10, Bookings: 20},
+		{CategoryID: uuid.New(), CategoryName: "Decor", ApprovedVendors: 2, Bookings: 4},
+		{CategoryID: uuid.New(), CategoryName: "Drone", ApprovedVendors: 0, Bookings: 0},
+		{CategoryID: uuid.New(), CategoryName: "Venues", ApprovedVendors: 5, Bookings: 25},
+		{CategoryID: uuid.New(), CategoryName: "DJ", ApprovedVendors: 0, Bookings: 3},
+	}
+
+	tests := []struct {
+		name string
+		req  *pb.GetCategoryUsageRequest
+		want []string
+	}{
+		{
+			name: "underserved first, then bookings per vendor",
+			req:  &pb.GetCategoryUsageRequest{},
+			want: []string{"DJ", "Decor", "Venues", "Catering", "Drone"},
+		},
+		{
+			name: "underserved only",
+			req:  &pb.GetCategoryUsageRequest{UnderservedOnly: true},
+			want: []string{"DJ", "Decor"},
+		},
+		{
+			name: "custom minimum",
+			req:  &pb.GetCategoryUsageRequest{MinVendors: 6, UnderservedOnly: true},
+			want: []string{"Venues", "DJ", "Decor"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &stubRepo{
+				getCategoryUsage: func(context.Context, adminModel.Period) ([]adminModel.CategoryUsage, error) {
+					return usage, nil
+				},
+			}
+
+			resp, err := newTestService(repo).GetCategoryUsage(context.Background(), tt.req)
+			if err != nil {
+				t.Fatalf("GetCategoryUsage() error = %v", err)
+			}
+
+			var got []string
+			for _, c := range resp.Categories {
+				got = append(got, c.CategoryName)
+				if c.ApprovedVendors == 0 && c.BookingsPerVendor != float64(c.Bookings) {
+					t.Errorf("%s: bookings per vendor = %v with no vendors, want %v", c.CategoryName, c.BookingsPerVendor, c.Bookings)
+				}
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("GetCategoryUsage() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSumTimeSeries(t *testing.T) {
+	tests := []struct {
+		name   string
+		points []adminModel.TimeSeriesPoint
+		want   adminModel.TimeSeriesPoint
+	}{
+		{name: "no points"},
+		{
+			name: "buckets add up",
+			points: []adminModel.TimeSeriesPoint{
+				{Bucket: time.Now(), Bookings: 2, Revenue: 700, NewClients: 1, WalletInflow: 50.5},
+				{Bucket: time.Now(), Bookings: 3, Revenue: 300, NewVendors: 2, WalletOutflow: 20.25},
+			},
+			want: adminModel.TimeSeriesPoint{Bookings: 5, Revenue: 1000, NewClients: 1, NewVendors: 2, WalletInflow: 50.5, WalletOutflow: 20.25},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := sumTimeSeries(tt.points); got != tt.want {
+				t.Errorf("sumTimeSeries() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGetTimeSeriesAnalytics(t *testing.T) {
+	to := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+	from := to.AddDate(0, 0, -7)
+
+	tests := []struct {
+		name        string
+		req         *pb.GetTimeSeriesAnalyticsRequest
+		wantCode    codes.Code
+		granularity string
+	}{
+		{
+			name:        "day by default",
+			req:         &pb.GetTimeSeriesAnalyticsRequest{From: timestamppb.New(from), To: timestamppb.New(to)},
+			granularity: adminModel.GranularityDay,
+		},
+		{
+			name:        "weekly",
+			req:         &pb.GetTimeSeriesAnalyticsRequest{From: timestamppb.New(from), To: timestamppb.New(to), Granularity: adminModel.GranularityWeek},
+			granularity: adminModel.GranularityWeek,
+		},
+		{
+			name:     "unknown granularity",
+			req:      &pb.GetTimeSeriesAnalyticsRequest{From: timestamppb.New(from), To: timestamppb.New(to), Granularity: "hour"},
+			wantCode: codes.InvalidArgument,
+		},
+		{
+			name:     "too many daily buckets",
+			req:      &pb.GetTimeSeriesAnalyticsRequest{From: timestamppb.New(to.AddDate(-2, 0, 0)), To: timestamppb.New(to)},
+			wantCode: codes.InvalidArgument,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var periods []adminModel.Period
+			repo := &stubRepo{
+				getTimeSeries: func(_ context.Context, period adminModel.Period, granularity string) ([]adminModel.TimeSeriesPoint, error) {
+					if granularity != tt.granularity {
+						t.Errorf("granularity = %q, want %q", granularity, tt.granularity)
+					}
+					periods = append(periods, period)
+					if len(periods) == 1 {
+						return []adminModel.TimeSeriesPoint{{Bucket: from, Bookings: 3, Revenue: 600}}, nil
+					}
+					return []adminModel.TimeSeriesPoint{{Bucket: from.AddDate(0, 0, -7), Bookings: 2, Revenue: 400}}, nil
+				},
+			}
+
+			resp, err := newTestService(repo).GetTimeSeriesAnalytics(context.Background(), tt.req)
+			if got := status.Code(err); got != tt.wantCode {
+				t.Fatalf("GetTimeSeriesAnalytics() = %v, want %v (%v)", got, tt.wantCode, err)
+			}
+			if err != nil {
+				return
+			}
+
+			wantPeriods := []adminModel.Period{{From: from, To: to}, {From: from.AddDate(0, 0, -7), To: from}}
+			if !reflect.DeepEqual(periods, wantPeriods) {
+				t.Errorf("queried periods %+v, want %+v", periods, wantPeriods)
+			}
+
+			revenue := resp.Comparison[0]
+			if revenue.Metric != "revenue" || revenue.Current != 600 || revenue.Previous != 400 || revenue.ChangePercent == nil || *revenue.ChangePercent != 50 {
+				t.Errorf("revenue comparison = %+v, want 600 against 400 (+50%%)", revenue)
+			}
+		})
+	}
+}
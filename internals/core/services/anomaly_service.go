@@ -0,0 +1,290 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	pb "github.com/AthulKrishna2501/proto-repo/admin"
+	adminModel "github.com/AthulKrishna2501/zyra-admin-service/internals/core/models"
+	"github.com/AthulKrishna2501/zyra-admin-service/internals/core/repository"
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"gorm.io/gorm"
+)
+
+const (
+	anomalyScanInterval = 5 * time.Minute
+
+	// Each scan looks back this far. Scans overlap, and alerts that were
+	// already raised are skipped, so a scan that fails or is late does not
+	// leave a gap.
+	anomalyScanLookback = 24 * time.Hour
+)
+
+func (s *AdminService) GetAnomalyRules(ctx context.Context, req *pb.GetAnomalyRulesRequest) (*pb.GetAnomalyRulesResponse, error) {
+	settings, err := s.AdminRepo.GetAnomalySettings(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Failed to fetch anomaly rules: %v", err)
+	}
+
+	return &pb.GetAnomalyRulesResponse{Rules: toPbAnomalyRules(settings)}, nil
+}
+
+func (s *AdminService) UpdateAnomalyRules(ctx context.Context, req *pb.UpdateAnomalyRulesRequest) (*pb.UpdateAnomalyRulesResponse, error) {
+	if req.Rules == nil {
+		return nil, status.Errorf(codes.InvalidArgument, "Rules are required")
+	}
+	if req.UpdatedBy == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "UpdatedBy is required")
+	}
+
+	rules := req.Rules
+	switch {
+	case rules.SpikeZScore <= 0:
+		return nil, status.Errorf(codes.InvalidArgument, "Spike z-score must be positive")
+	case rules.SpikeBaselineDays < 1 || rules.SpikeBaselineDays > 365:
+		return nil, status.Errorf(codes.InvalidArgument, "Spike baseline must be between 1 and 365 days")
+	case rules.SpikeMinSamples < 2:
+		return nil, status.Errorf(codes.InvalidArgument, "Spike baseline needs at least 2 samples")
+	case rules.RepeatedPayoutCount < 2 || rules.VelocityMaxCount < 2:
+		return nil, status.Errorf(codes.InvalidArgument, "Window thresholds must be at least 2")
+	case rules.RepeatedPayoutWindowHours < 1 || rules.VelocityWindowMinutes < 1:
+		return nil, status.Errorf(codes.InvalidArgument, "Windows must be positive")
+	}
+
+	settings := &adminModel.AnomalySettings{
+		Enabled:                   rules.Enabled,
+		SpikeZScore:               rules.SpikeZScore,
+		SpikeBaselineDays:         int(rules.SpikeBaselineDays),
+		SpikeMinSamples:           int(rules.SpikeMinSamples),
+		RepeatedPayoutCount:       int(rules.RepeatedPayoutCount),
+		RepeatedPayoutWindowHours: int(rules.RepeatedPayoutWindowHours),
+		VelocityMaxCount:          int(rules.VelocityMaxCount),
+		VelocityWindowMinutes:     int(rules.VelocityWindowMinutes),
+		UpdatedBy:                 req.UpdatedBy,
+	}
+	if err := s.AdminRepo.SaveAnomalySettings(ctx, settings); err != nil {
+		return nil, status.Errorf(codes.Internal, "Failed to save anomaly rules: %v", err)
+	}
+
+	s.log.Info("Admin Service: anomaly rules updated", req.UpdatedBy, settings.Enabled)
+
+	return &pb.UpdateAnomalyRulesResponse{Rules: toPbAnomalyRules(settings)}, nil
+}
+
+func (s *AdminService) ListAnomalyAlerts(ctx context.Context, req *pb.ListAnomalyAlertsRequest) (*pb.ListAnomalyAlertsResponse, error) {
+	filter := adminModel.AnomalyAlertFilter{
+		Status: strings.ToLower(strings.TrimSpace(req.Status)),
+		Rule:   strings.TrimSpace(req.Rule),
+		Source: strings.TrimSpace(req.Source),
+		UserID: req.UserId,
+	}
+
+	if filter.Status != "" && filter.Status != adminModel.AnomalyAlertOpen && filter.Status != adminModel.AnomalyAlertAcknowledged {
+		return nil, status.Errorf(codes.InvalidArgument, "Invalid status. Allowed values: 'open', 'acknowledged'")
+	}
+	if filter.UserID != "" {
+		if _, err := uuid.Parse(filter.UserID); err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "Invalid user ID: %v", err)
+		}
+	}
+
+	filter.Limit, filter.Offset = pageBounds(req.Page, req.PageSize)
+
+	alerts, total, err := s.AdminRepo.ListAnomalyAlerts(ctx, filter)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Failed to fetch anomaly alerts: %v", err)
+	}
+
+	var pbAlerts []*pb.AnomalyAlert
+	for _, alert := range alerts {
+		pbAlerts = append(pbAlerts, toPbAnomalyAlert(&alert))
+	}
+
+	return &pb.ListAnomalyAlertsResponse{
+		Alerts: pbAlerts,
+		Total:  int32(total),
+	}, nil
+}
+
+func (s *AdminService) AcknowledgeAnomalyAlert(ctx context.Context, req *pb.AcknowledgeAnomalyAlertRequest) (*pb.AcknowledgeAnomalyAlertResponse, error) {
+	if _, err := uuid.Parse(req.AlertId); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "Invalid alert ID: %v", err)
+	}
+	if req.AdminEmail == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "Admin email is required")
+	}
+
+	alert, err := s.AdminRepo.AcknowledgeAnomalyAlert(ctx, req.AlertId, req.AdminEmail, strings.TrimSpace(req.Note))
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, status.Errorf(codes.NotFound, "Alert %s not found", req.AlertId)
+	} else if errors.Is(err, repository.ErrAlertAcknowledged) {
+		return nil, status.Errorf(codes.FailedPrecondition, "Alert %s is already acknowledged", req.AlertId)
+	} else if err != nil {
+		return nil, status.Errorf(codes.Internal, "Failed to acknowledge alert: %v", err)
+	}
+
+	s.log.Info("Admin Service: anomaly alert acknowledged", req.AlertId, req.AdminEmail)
+
+	return &pb.AcknowledgeAnomalyAlertResponse{Alert: toPbAnomalyAlert(alert)}, nil
+}
+
+// StartAnomalyDetector scans recent money movements against the anomaly
+// rules until ctx is cancelled.
+func (s *AdminService) StartAnomalyDetector(ctx context.Context) {
+	ticker := time.NewTicker(anomalyScanInterval)
+	defer ticker.Stop()
+
+	for {
+		s.runAnomalyScan(ctx, time.Now())
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (s *AdminService) runAnomalyScan(ctx context.Context, now time.Time) {
+	settings, err := s.AdminRepo.GetAnomalySettings(ctx)
+	if err != nil {
+		s.log.Error("Admin Service: failed to load anomaly rules", err)
+		return
+	}
+	if !settings.Enabled {
+		return
+	}
+
+	alerts, err := s.detectAnomalies(ctx, settings, adminModel.Period{From: now.Add(-anomalyScanLookback), To: now})
+	if err != nil {
+		s.log.Error("Admin Service: anomaly scan failed", err)
+		return
+	}
+
+	created, err := s.AdminRepo.SaveAnomalyAlerts(ctx, alerts)
+	if err != nil {
+		s.log.Error("Admin Service: failed to save anomaly alerts", err)
+	}
+
+	for _, alert := range created {
+		s.log.Warn("Admin Service: anomaly detected", alert.Rule, alert.Source, alert.Details)
+	}
+}
+
+// detectAnomalies runs every rule over the period and describes what each
+// candidate broke.
+func (s *AdminService) detectAnomalies(ctx context.Context, settings *adminModel.AnomalySettings, period adminModel.Period) ([]adminModel.AnomalyAlert, error) {
+	var alerts []adminModel.AnomalyAlert
+
+	spikes, err := s.AdminRepo.FindAmountSpikes(ctx, period, settings)
+	if err != nil {
+		return nil, fmt.Errorf("amount spikes: %w", err)
+	}
+	for _, c := range spikes {
+		alerts = append(alerts, anomalyAlert(adminModel.AnomalyRuleAmountSpike, c, fmt.Sprintf(
+			"amount %.2f is %.1f standard deviations above the mean of %.2f over %d earlier movements",
+			c.Amount, c.Score, c.Mean, c.Samples,
+		)))
+	}
+
+	payoutWindow := time.Duration(settings.RepeatedPayoutWindowHours) * time.Hour
+	payouts, err := s.AdminRepo.FindMovementBursts(ctx, period,
+		[]string{adminModel.AnomalySourceFundRelease}, payoutWindow, settings.RepeatedPayoutCount)
+	if err != nil {
+		return nil, fmt.Errorf("repeated payouts: %w", err)
+	}
+	for _, c := range payouts {
+		if c.UserID == nil {
+			// Fund releases for events that no longer exist have no host
+			// to group by.
+			continue
+		}
+		alerts = append(alerts, anomalyAlert(adminModel.AnomalyRuleRepeatedPayout, c, fmt.Sprintf(
+			"%d fund release requests totalling %.2f for host %s within %s, threshold %d",
+			c.Count, c.Amount, c.UserID, payoutWindow, settings.RepeatedPayoutCount,
+		)))
+	}
+
+	velocityWindow := time.Duration(settings.VelocityWindowMinutes) * time.Minute
+	bursts, err := s.AdminRepo.FindMovementBursts(ctx, period,
+		[]string{adminModel.AnomalySourceAdminWallet, adminModel.AnomalySourceClientCredit}, velocityWindow, settings.VelocityMaxCount)
+	if err != nil {
+		return nil, fmt.Errorf("velocity: %w", err)
+	}
+	for _, c := range bursts {
+		subject := "the admin wallet"
+		if c.UserID != nil {
+			subject = "wallet of " + c.UserID.String()
+		}
+		alerts = append(alerts, anomalyAlert(adminModel.AnomalyRuleVelocity, c, fmt.Sprintf(
+			"%d movements totalling %.2f on %s within %s, threshold %d",
+			c.Count, c.Amount, subject, velocityWindow, settings.VelocityMaxCount,
+		)))
+	}
+
+	return alerts, nil
+}
+
+func anomalyAlert(rule string, c adminModel.AnomalyCandidate, details string) adminModel.AnomalyAlert {
+	return adminModel.AnomalyAlert{
+		Rule:       rule,
+		Source:     c.Source,
+		SubjectKey: c.SubjectKey,
+		UserID:     c.UserID,
+		Amount:     c.Amount,
+		Score:      c.Score,
+		Details:    details,
+		OccurredAt: c.OccurredAt,
+		Status:     adminModel.AnomalyAlertOpen,
+	}
+}
+
+func toPbAnomalyRules(settings *adminModel.AnomalySettings) *pb.AnomalyRules {
+	rules := &pb.AnomalyRules{
+		Enabled:                   settings.Enabled,
+		SpikeZScore:               settings.SpikeZScore,
+		SpikeBaselineDays:         int32(settings.SpikeBaselineDays),
+		SpikeMinSamples:           int32(settings.SpikeMinSamples),
+		RepeatedPayoutCount:       int32(settings.RepeatedPayoutCount),
+		RepeatedPayoutWindowHours: int32(settings.RepeatedPayoutWindowHours),
+		VelocityMaxCount:          int32(settings.VelocityMaxCount),
+		VelocityWindowMinutes:     int32(settings.VelocityWindowMinutes),
+		UpdatedBy:                 settings.UpdatedBy,
+	}
+	if !settings.UpdatedAt.IsZero() {
+		rules.UpdatedAt = timestamppb.New(settings.UpdatedAt)
+	}
+
+	return rules
+}
+
+func toPbAnomalyAlert(alert *adminModel.AnomalyAlert) *pb.AnomalyAlert {
+	pbAlert := &pb.AnomalyAlert{
+		AlertId:        alert.AlertID.String(),
+		Rule:           alert.Rule,
+		Source:         alert.Source,
+		SubjectKey:     alert.SubjectKey,
+		Amount:         alert.Amount,
+		Score:          alert.Score,
+		Details:        alert.Details,
+		Status:         alert.Status,
+		OccurredAt:     timestamppb.New(alert.OccurredAt),
+		DetectedAt:     timestamppb.New(alert.CreatedAt),
+		AcknowledgedBy: alert.AcknowledgedBy,
+		Note:           alert.Note,
+	}
+	if alert.UserID != nil {
+		pbAlert.UserId = alert.UserID.String()
+	}
+	if alert.AcknowledgedAt != nil {
+		pbAlert.AcknowledgedAt = timestamppb.New(*alert.AcknowledgedAt)
+	}
+
+	return pbAlert
+}
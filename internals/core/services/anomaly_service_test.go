@@ -0,0 +1,72 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	adminModel "github.com/AthulKrishna2501/zyra-admin-service/internals/core/models"
+	"github.com/google/uuid"
+)
+
+func TestDetectAnomalies(t *testing.T) {
+	host := uuid.New()
+	settings := &adminModel.AnomalySettings{
+		RepeatedPayoutCount:       3,
+		RepeatedPayoutWindowHours: 24,
+		VelocityMaxCount:          10,
+		VelocityWindowMinutes:     60,
+	}
+
+	repo := &stubRepo{
+		findAmountSpikes: func(context.Context, adminModel.Period, *adminModel.AnomalySettings) ([]adminModel.AnomalyCandidate, error) {
+			return []adminModel.AnomalyCandidate{
+				{Source: adminModel.AnomalySourceAdminWallet, SubjectKey: "txn-1", Amount: 9000, Score: 4.2},
+			}, nil
+		},
+		findMovementBursts: func(_ context.Context, _ adminModel.Period, sources []string, window time.Duration, threshold int) ([]adminModel.AnomalyCandidate, error) {
+			switch sources[0] {
+			case adminModel.AnomalySourceFundRelease:
+				if window != 24*time.Hour || threshold != 3 {
+					t.Errorf("payout window %v threshold %d, want 24h and 3", window, threshold)
+				}
+				return []adminModel.AnomalyCandidate{
+					{Source: adminModel.AnomalySourceFundRelease, SubjectKey: "release-3", UserID: &host, Count: 3},
+					{Source: adminModel.AnomalySourceFundRelease, SubjectKey: "release-9", Count: 4},
+				}, nil
+			default:
+				if window != time.Hour || threshold != 10 {
+					t.Errorf("velocity window %v threshold %d, want 1h and 10", window, threshold)
+				}
+				return []adminModel.AnomalyCandidate{
+					{Source: adminModel.AnomalySourceAdminWallet, SubjectKey: "txn-12", Count: 10},
+				}, nil
+			}
+		},
+	}
+
+	alerts, err := newTestService(repo).detectAnomalies(context.Background(), settings, adminModel.Period{})
+	if err != nil {
+		t.Fatalf("detectAnomalies() error = %v", err)
+	}
+
+	want := []struct {
+		rule       string
+		subjectKey string
+	}{
+		{adminModel.AnomalyRuleAmountSpike, "txn-1"},
+		{adminModel.AnomalyRuleRepeatedPayout, "release-3"},
+		{adminModel.AnomalyRuleVelocity, "txn-12"},
+	}
+	if len(alerts) != len(want) {
+		t.Fatalf("got %d alerts, want %d: %+v", len(alerts), len(want), alerts)
+	}
+	for i, w := range want {
+		if alerts[i].Rule != w.rule || alerts[i].SubjectKey != w.subjectKey {
+			t.Errorf("alert %d = %s/%s, want %s/%s", i, alerts[i].Rule, alerts[i].SubjectKey, w.rule, w.subjectKey)
+		}
+		if alerts[i].Status != adminModel.AnomalyAlertOpen {
+			t.Errorf("alert %d status = %s, want open", i, alerts[i].Status)
+		}
+	}
+}
@@ -0,0 +1,266 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	pb "github.com/AthulKrishna2501/proto-repo/admin"
+	adminModel "github.com/AthulKrishna2501/zyra-admin-service/internals/core/models"
+	"github.com/AthulKrishna2501/zyra-admin-service/internals/core/repository"
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"gorm.io/gorm"
+)
+
+const (
+	autoApprovalInterval  = 30 * time.Second
+	autoApprovalBatchSize = 50
+)
+
+// ruleCheck is the outcome of one auto-approval rule, kept so every
+// decision can be explained.
+type ruleCheck struct {
+	passed bool
+	detail string
+}
+
+func (s *AdminService) GetAutoApprovalRules(ctx context.Context, req *pb.GetAutoApprovalRulesRequest) (*pb.GetAutoApprovalRulesResponse, error) {
+	settings, err := s.AdminRepo.GetAutoApprovalSettings(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Failed to fetch auto-approval rules: %v", err)
+	}
+
+	return &pb.GetAutoApprovalRulesResponse{Rules: toPbAutoApprovalRules(settings)}, nil
+}
+
+func (s *AdminService) UpdateAutoApprovalRules(ctx context.Context, req *pb.UpdateAutoApprovalRulesRequest) (*pb.UpdateAutoApprovalRulesResponse, error) {
+	if req.Rules == nil {
+		return nil, status.Errorf(codes.InvalidArgument, "Rules are required")
+	}
+	if req.UpdatedBy == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "UpdatedBy is required")
+	}
+	if req.Rules.MinAccountAgeDays < 0 || req.Rules.MinCompletedBookings < 0 || req.Rules.MaxDisputes < 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "Rule thresholds cannot be negative")
+	}
+
+	settings := &adminModel.AutoApprovalSettings{
+		Enabled:              req.Rules.Enabled,
+		MinAccountAgeDays:    int(req.Rules.MinAccountAgeDays),
+		MinCompletedBookings: int(req.Rules.MinCompletedBookings),
+		MaxDisputes:          int(req.Rules.MaxDisputes),
+		UpdatedBy:            req.UpdatedBy,
+	}
+	if err := s.AdminRepo.SaveAutoApprovalSettings(ctx, settings); err != nil {
+		return nil, status.Errorf(codes.Internal, "Failed to save auto-approval rules: %v", err)
+	}
+
+	s.log.Info("Admin Service: auto-approval rules updated", req.UpdatedBy, settings.Enabled)
+
+	return &pb.UpdateAutoApprovalRulesResponse{Rules: toPbAutoApprovalRules(settings)}, nil
+}
+
+func (s *AdminService) ListAutoApprovalEvaluations(ctx context.Context, req *pb.ListAutoApprovalEvaluationsRequest) (*pb.ListAutoApprovalEvaluationsResponse, error) {
+	if req.RequestId != "" {
+		if _, err := uuid.Parse(req.RequestId); err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "Invalid request ID: %v", err)
+		}
+	}
+
+	limit, offset := pageBounds(req.Page, req.PageSize)
+	evaluations, total, err := s.AdminRepo.ListAutoApprovalEvaluations(ctx, req.RequestId, limit, offset)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Failed to fetch auto-approval evaluations: %v", err)
+	}
+
+	var pbEvaluations []*pb.AutoApprovalEvaluation
+	for _, e := range evaluations {
+		pbEvaluations = append(pbEvaluations, &pb.AutoApprovalEvaluation{
+			EvaluationId: e.EvaluationID.String(),
+			RequestId:    e.RequestID.String(),
+			Outcome:      e.Outcome,
+			Explanation:  e.Explanation,
+			EvaluatedAt:  timestamppb.New(e.CreatedAt),
+		})
+	}
+
+	return &pb.ListAutoApprovalEvaluationsResponse{
+		Evaluations: pbEvaluations,
+		Total:       int32(total),
+	}, nil
+}
+
+// StartAutoApprovalWorker evaluates new category requests against the
+// auto-approval rules until ctx is cancelled.
+func (s *AdminService) StartAutoApprovalWorker(ctx context.Context) {
+	ticker := time.NewTicker(autoApprovalInterval)
+	defer ticker.Stop()
+
+	for {
+		s.runAutoApproval(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (s *AdminService) runAutoApproval(ctx context.Context) {
+	settings, err := s.AdminRepo.GetAutoApprovalSettings(ctx)
+	if err != nil {
+		s.log.Error("Admin Service: failed to load auto-approval rules", err)
+		return
+	}
+	if !settings.Enabled {
+		return
+	}
+
+	requests, err := s.AdminRepo.ListUnevaluatedCategoryRequests(ctx, autoApprovalBatchSize)
+	if err != nil {
+		s.log.Error("Admin Service: failed to fetch category requests for auto-approval", err)
+		return
+	}
+
+	for _, request := range requests {
+		if err := s.autoEvaluate(ctx, settings, request); err != nil {
+			s.log.Error("Admin Service: auto-approval failed", request.RequestID.String(), err)
+		}
+	}
+}
+
+// autoEvaluate runs the rules on one request. A request that passes every
+// rule is approved as the auto-approval actor with the explanation as
+// notes, together with its evaluation; any other request is left pending
+// for an admin and only the evaluation is recorded.
+func (s *AdminService) autoEvaluate(ctx context.Context, settings *adminModel.AutoApprovalSettings, request adminModel.CategoryRequest) error {
+	checks, err := s.autoApprovalChecks(ctx, settings, request)
+	if err != nil {
+		return err
+	}
+
+	approve := true
+	var details []string
+	for _, check := range checks {
+		approve = approve && check.passed
+		details = append(details, check.detail)
+	}
+
+	outcome := adminModel.AutoApprovalOutcomeManualReview
+	explanation := "routed to manual review: " + strings.Join(details, "; ")
+
+	if approve {
+		evaluation := &adminModel.AutoApprovalEvaluation{
+			RequestID:   request.RequestID,
+			Outcome:     adminModel.AutoApprovalOutcomeApproved,
+			Explanation: "auto-approved: " + strings.Join(details, "; "),
+		}
+		err := s.AdminRepo.AutoApproveCategoryRequest(ctx, adminModel.CategoryDecision{
+			Status:    adminModel.CategoryRequestApproved,
+			Notes:     evaluation.Explanation,
+			DecidedBy: adminModel.AutoApprovalActor,
+		}, evaluation)
+		switch {
+		case err == nil:
+			s.log.Info("Admin Service: category request auto-evaluated", request.RequestID.String(), evaluation.Outcome)
+			return nil
+		case errors.Is(err, repository.ErrRequestAlreadyDecided):
+			explanation = "skipped: request was decided by an admin first"
+		case errors.Is(err, repository.ErrRequestAssigned):
+			explanation = "skipped: request is assigned to an admin"
+		default:
+			return err
+		}
+	}
+
+	err = s.AdminRepo.RecordAutoApprovalEvaluation(ctx, &adminModel.AutoApprovalEvaluation{
+		RequestID:   request.RequestID,
+		Outcome:     outcome,
+		Explanation: explanation,
+	})
+	if err != nil {
+		return err
+	}
+
+	s.log.Info("Admin Service: category request auto-evaluated", request.RequestID.String(), outcome)
+	return nil
+}
+
+func (s *AdminService) autoApprovalChecks(ctx context.Context, settings *adminModel.AutoApprovalSettings, request adminModel.CategoryRequest) ([]ruleCheck, error) {
+	var checks []ruleCheck
+
+	if request.AssignedTo != "" {
+		checks = append(checks, ruleCheck{detail: fmt.Sprintf("already assigned to %s", request.AssignedTo)})
+	}
+
+	if _, err := s.AdminRepo.GetCategory(ctx, request.CategoryID.String()); err != nil {
+		checks = append(checks, ruleCheck{detail: "category no longer exists"})
+	}
+
+	standing, err := s.AdminRepo.GetVendorStanding(ctx, request.VendorID.String())
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return append(checks, ruleCheck{detail: "vendor account not found"}), nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	// Same gate as every other vendor action, so vendors approved before
+	// document verification existed are treated as verified here too.
+	err = s.requireVerifiedVendor(ctx, request.VendorID.String())
+	switch status.Code(err) {
+	case codes.OK:
+		checks = append(checks, ruleCheck{passed: true, detail: "vendor verified (passed)"})
+	case codes.FailedPrecondition, codes.NotFound:
+		checks = append(checks, ruleCheck{detail: fmt.Sprintf("%s (failed)", status.Convert(err).Message())})
+	default:
+		return nil, err
+	}
+
+	ageDays := int(time.Since(standing.AccountCreatedAt).Hours() / 24)
+	checks = append(checks, ruleCheck{
+		passed: ageDays >= settings.MinAccountAgeDays,
+		detail: fmt.Sprintf("account age %d days, minimum %d (%s)", ageDays, settings.MinAccountAgeDays, passFail(ageDays >= settings.MinAccountAgeDays)),
+	})
+
+	completed := standing.CompletedBookings >= int64(settings.MinCompletedBookings)
+	checks = append(checks, ruleCheck{
+		passed: completed,
+		detail: fmt.Sprintf("%d completed bookings, minimum %d (%s)", standing.CompletedBookings, settings.MinCompletedBookings, passFail(completed)),
+	})
+
+	disputes := standing.Disputes <= int64(settings.MaxDisputes)
+	checks = append(checks, ruleCheck{
+		passed: disputes,
+		detail: fmt.Sprintf("%d disputed bookings, maximum %d (%s)", standing.Disputes, settings.MaxDisputes, passFail(disputes)),
+	})
+
+	return checks, nil
+}
+
+func passFail(passed bool) string {
+	if passed {
+		return "passed"
+	}
+	return "failed"
+}
+
+func toPbAutoApprovalRules(settings *adminModel.AutoApprovalSettings) *pb.AutoApprovalRules {
+	rules := &pb.AutoApprovalRules{
+		Enabled:              settings.Enabled,
+		MinAccountAgeDays:    int32(settings.MinAccountAgeDays),
+		MinCompletedBookings: int32(settings.MinCompletedBookings),
+		MaxDisputes:          int32(settings.MaxDisputes),
+		UpdatedBy:            settings.UpdatedBy,
+	}
+	if !settings.UpdatedAt.IsZero() {
+		rules.UpdatedAt = timestamppb.New(settings.UpdatedAt)
+	}
+
+	return rules
+}
@@ -0,0 +1,194 @@
+package services
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	adminModel "github.com/AthulKrishna2501/zyra-admin-service/internals/core/models"
+	"github.com/AthulKrishna2501/zyra-admin-service/internals/core/repository"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+func TestAutoEvaluate(t *testing.T) {
+	settings := &adminModel.AutoApprovalSettings{
+		Enabled:              true,
+		MinAccountAgeDays:    30,
+		MinCompletedBookings: 5,
+		MaxDisputes:          1,
+	}
+	good := adminModel.VendorStanding{
+		AccountCreatedAt:  time.Now().AddDate(0, 0, -90),
+		CompletedBookings: 12,
+	}
+
+	tests := []struct {
+		name        string
+		assignedTo  string
+		categoryErr error
+		standing    func(s *adminModel.VendorStanding)
+		standingErr error
+		// verification overrides the approved latest verification;
+		// noVerification leaves the vendor without one so userStatus decides.
+		verification   string
+		noVerification bool
+		userStatus     string
+		decideErr      error
+		wantDecide     bool
+		wantOutcome    string
+		wantDetail     string
+	}{
+		{
+			name:        "every rule passes",
+			wantDecide:  true,
+			wantOutcome: adminModel.AutoApprovalOutcomeApproved,
+			wantDetail:  "auto-approved:",
+		},
+		{
+			name:           "legacy approved vendor",
+			noVerification: true,
+			userStatus:     adminModel.VerificationStatusApproved,
+			wantDecide:     true,
+			wantOutcome:    adminModel.AutoApprovalOutcomeApproved,
+			wantDetail:     "vendor verified (passed)",
+		},
+		{
+			name:         "verification rejected",
+			verification: adminModel.VerificationStatusRejected,
+			wantOutcome:  adminModel.AutoApprovalOutcomeManualReview,
+			wantDetail:   "is not verified, verification is rejected (failed)",
+		},
+		{
+			name:           "verification not submitted",
+			noVerification: true,
+			userStatus:     "pending",
+			wantOutcome:    adminModel.AutoApprovalOutcomeManualReview,
+			wantDetail:     "has not submitted verification documents (failed)",
+		},
+		{
+			name:        "new account",
+			standing:    func(s *adminModel.VendorStanding) { s.AccountCreatedAt = time.Now().AddDate(0, 0, -3) },
+			wantOutcome: adminModel.AutoApprovalOutcomeManualReview,
+			wantDetail:  "account age 3 days, minimum 30 (failed)",
+		},
+		{
+			name:        "too few bookings",
+			standing:    func(s *adminModel.VendorStanding) { s.CompletedBookings = 4 },
+			wantOutcome: adminModel.AutoApprovalOutcomeManualReview,
+			wantDetail:  "4 completed bookings, minimum 5 (failed)",
+		},
+		{
+			name:        "too many disputes",
+			standing:    func(s *adminModel.VendorStanding) { s.Disputes = 2 },
+			wantOutcome: adminModel.AutoApprovalOutcomeManualReview,
+			wantDetail:  "2 disputed bookings, maximum 1 (failed)",
+		},
+		{
+			name:        "assigned to an admin",
+			assignedTo:  "admin@zyra.com",
+			wantOutcome: adminModel.AutoApprovalOutcomeManualReview,
+			wantDetail:  "already assigned to admin@zyra.com",
+		},
+		{
+			name:        "category gone",
+			categoryErr: gorm.ErrRecordNotFound,
+			wantOutcome: adminModel.AutoApprovalOutcomeManualReview,
+			wantDetail:  "category no longer exists",
+		},
+		{
+			name:        "vendor gone",
+			standingErr: gorm.ErrRecordNotFound,
+			wantOutcome: adminModel.AutoApprovalOutcomeManualReview,
+			wantDetail:  "vendor account not found",
+		},
+		{
+			name:        "decided by an admin first",
+			decideErr:   repository.ErrRequestAlreadyDecided,
+			wantDecide:  true,
+			wantOutcome: adminModel.AutoApprovalOutcomeManualReview,
+			wantDetail:  "skipped: request was decided by an admin first",
+		},
+		{
+			name:        "assigned after it was read",
+			decideErr:   repository.ErrRequestAssigned,
+			wantDecide:  true,
+			wantOutcome: adminModel.AutoApprovalOutcomeManualReview,
+			wantDetail:  "skipped: request is assigned to an admin",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			standing := good
+			if tt.standing != nil {
+				tt.standing(&standing)
+			}
+			verification := adminModel.VerificationStatusApproved
+			if tt.verification != "" {
+				verification = tt.verification
+			}
+
+			var decided bool
+			var recorded *adminModel.AutoApprovalEvaluation
+			repo := &stubRepo{
+				getCategory: func(context.Context, string) (*adminModel.Category, error) {
+					return &adminModel.Category{}, tt.categoryErr
+				},
+				getVendorStanding: func(context.Context, string) (*adminModel.VendorStanding, error) {
+					if tt.standingErr != nil {
+						return nil, tt.standingErr
+					}
+					return &standing, nil
+				},
+				getLatestVendorVerification: func(context.Context, string) (*adminModel.VendorVerification, error) {
+					if tt.noVerification {
+						return nil, gorm.ErrRecordNotFound
+					}
+					return &adminModel.VendorVerification{Status: verification}, nil
+				},
+				getUserStatus: func(context.Context, string) (string, error) {
+					return tt.userStatus, nil
+				},
+				autoApproveCategoryRequest: func(_ context.Context, decision adminModel.CategoryDecision, evaluation *adminModel.AutoApprovalEvaluation) error {
+					decided = true
+					if decision.DecidedBy != adminModel.AutoApprovalActor {
+						t.Errorf("decided by %q, want %q", decision.DecidedBy, adminModel.AutoApprovalActor)
+					}
+					if tt.decideErr == nil {
+						recorded = evaluation
+					}
+					return tt.decideErr
+				},
+				recordAutoApprovalEvaluation: func(_ context.Context, evaluation *adminModel.AutoApprovalEvaluation) error {
+					recorded = evaluation
+					return nil
+				},
+			}
+
+			request := adminModel.CategoryRequest{
+				RequestID:  uuid.New(),
+				VendorID:   uuid.New(),
+				CategoryID: uuid.New(),
+				AssignedTo: tt.assignedTo,
+			}
+			if err := newTestService(repo).autoEvaluate(context.Background(), settings, request); err != nil {
+				t.Fatalf("autoEvaluate() error = %v", err)
+			}
+
+			if decided != tt.wantDecide {
+				t.Errorf("decided = %v, want %v", decided, tt.wantDecide)
+			}
+			if recorded == nil {
+				t.Fatal("no evaluation recorded")
+			}
+			if recorded.Outcome != tt.wantOutcome {
+				t.Errorf("outcome = %q, want %q", recorded.Outcome, tt.wantOutcome)
+			}
+			if !strings.Contains(recorded.Explanation, tt.wantDetail) {
+				t.Errorf("explanation %q does not mention %q", recorded.Explanation, tt.wantDetail)
+			}
+		})
+	}
+}
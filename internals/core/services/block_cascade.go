@@ -0,0 +1,159 @@
+package services
+
+import (
+	"context"
+	"fmt"
+
+	pb "github.com/AthulKrishna2501/proto-repo/admin"
+	adminModel "github.com/AthulKrishna2501/zyra-admin-service/internals/core/models"
+)
+
+const (
+	cascadeStepHideCategories    = "hide_from_categories"
+	cascadeStepCancelBookings    = "cancel_upcoming_bookings"
+	cascadeStepHoldPayouts       = "hold_payouts"
+	cascadeStepRestoreCategories = "restore_categories"
+	cascadeStepReleasePayouts    = "release_payouts"
+)
+
+// runBlockCascade applies the optional side effects of blocking a user. Each
+// step runs independently so one failure does not prevent the others, and
+// every step is reported back to the caller.
+func (s *AdminService) runBlockCascade(ctx context.Context, userID string, policy *pb.BlockCascadePolicy) []adminModel.CascadeStepResult {
+	var steps []adminModel.CascadeStepResult
+
+	reason := policy.Reason
+	if reason == "" {
+		reason = "user blocked"
+	}
+
+	// The vendor-only steps fail rather than skip when the role cannot be
+	// looked up, so a lookup error is never reported as success.
+	isVendor := false
+	var roleErr error
+	if policy.HideFromCategories || policy.CancelUpcomingBookings {
+		var role string
+		role, roleErr = s.AdminRepo.GetUserRole(ctx, userID)
+		isVendor = role == adminModel.RoleVendor
+	}
+
+	if policy.HideFromCategories {
+		step := adminModel.CascadeStepResult{Step: cascadeStepHideCategories}
+		if roleErr != nil {
+			step.Message = fmt.Sprintf("failed to look up user role: %v", roleErr)
+		} else if !isVendor {
+			step.Success = true
+			step.Message = "skipped, user is not a vendor"
+		} else if hidden, err := s.AdminRepo.HideVendorCategories(ctx, userID, reason); err != nil {
+			step.Message = fmt.Sprintf("failed to hide vendor categories: %v", err)
+		} else {
+			step.Success = true
+			step.Affected = hidden
+			step.Message = fmt.Sprintf("%d category memberships hidden", hidden)
+		}
+		steps = append(steps, step)
+	}
+
+	if policy.CancelUpcomingBookings {
+		step := adminModel.CascadeStepResult{Step: cascadeStepCancelBookings}
+		if roleErr != nil {
+			step.Message = fmt.Sprintf("failed to look up user role: %v", roleErr)
+		} else if !isVendor {
+			step.Success = true
+			step.Message = "skipped, user is not a vendor"
+		} else if cancelled, err := s.AdminRepo.CancelUpcomingVendorBookings(ctx, userID, adminWalletEmail); err != nil {
+			step.Message = fmt.Sprintf("failed to cancel upcoming bookings: %v", err)
+		} else {
+			refunds, amount := refundTotals(cancelled)
+			step.Success = true
+			step.Affected = int64(len(cancelled))
+			step.Message = fmt.Sprintf("%d bookings cancelled, %d refunded for %d in total", len(cancelled), refunds, amount)
+		}
+		steps = append(steps, step)
+	}
+
+	if policy.HoldPayouts {
+		step := adminModel.CascadeStepResult{Step: cascadeStepHoldPayouts}
+		if held, err := s.AdminRepo.HoldPayouts(ctx, userID, reason); err != nil {
+			step.Message = fmt.Sprintf("failed to hold payouts: %v", err)
+		} else {
+			step.Success = true
+			step.Affected = held
+			step.Message = fmt.Sprintf("payouts on hold, %d pending fund release requests parked", held)
+		}
+		steps = append(steps, step)
+	}
+
+	for _, step := range steps {
+		if !step.Success {
+			s.log.Error("Admin Service: block cascade step failed", userID, step.Step, step.Message)
+		}
+	}
+
+	return steps
+}
+
+// runUnblockCascade reverses the reversible block cascade steps. Cancelled
+// bookings stay cancelled since the clients have already been refunded.
+func (s *AdminService) runUnblockCascade(ctx context.Context, userID string) []adminModel.CascadeStepResult {
+	var steps []adminModel.CascadeStepResult
+
+	restore := adminModel.CascadeStepResult{Step: cascadeStepRestoreCategories}
+	if restored, err := s.AdminRepo.RestoreVendorCategories(ctx, userID); err != nil {
+		restore.Message = fmt.Sprintf("failed to restore vendor categories: %v", err)
+	} else if restored > 0 {
+		restore.Success = true
+		restore.Affected = restored
+		restore.Message = fmt.Sprintf("%d category memberships restored", restored)
+	}
+	if restore.Message != "" {
+		steps = append(steps, restore)
+	}
+
+	onHold, err := s.AdminRepo.HasActivePayoutHold(ctx, userID)
+	if err != nil {
+		steps = append(steps, adminModel.CascadeStepResult{
+			Step:    cascadeStepReleasePayouts,
+			Message: fmt.Sprintf("failed to check payout hold: %v", err),
+		})
+	} else if onHold {
+		release := adminModel.CascadeStepResult{Step: cascadeStepReleasePayouts}
+		if released, err := s.AdminRepo.ReleasePayoutHolds(ctx, userID); err != nil {
+			release.Message = fmt.Sprintf("failed to release payout hold: %v", err)
+		} else {
+			release.Success = true
+			release.Affected = released
+			release.Message = fmt.Sprintf("payout hold lifted, %d fund release requests back to pending", released)
+		}
+		steps = append(steps, release)
+	}
+
+	return steps
+}
+
+func toPbCascadeSteps(steps []adminModel.CascadeStepResult) []*pb.CascadeStepResult {
+	var pbSteps []*pb.CascadeStepResult
+	for _, step := range steps {
+		pbSteps = append(pbSteps, &pb.CascadeStepResult{
+			Step:     step.Step,
+			Success:  step.Success,
+			Affected: int32(step.Affected),
+			Message:  step.Message,
+		})
+	}
+
+	return pbSteps
+}
+
+// refundTotals counts the refunded bookings among cancelled and sums the
+// amount returned to their clients.
+func refundTotals(cancelled []adminModel.Booking) (refunds, amount int) {
+	for _, booking := range cancelled {
+		if booking.Refunded {
+			refunds++
+			amount += booking.Price
+		}
+	}
+
+	return refunds, amount
+}
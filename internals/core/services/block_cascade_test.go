@@ -0,0 +1,95 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	pb "github.com/AthulKrishna2501/proto-repo/admin"
+	adminModel "github.com/AthulKrishna2501/zyra-admin-service/internals/core/models"
+)
+
+func TestRunBlockCascade(t *testing.T) {
+	fullPolicy := &pb.BlockCascadePolicy{HideFromCategories: true, CancelUpcomingBookings: true, HoldPayouts: true}
+
+	tests := []struct {
+		name    string
+		role    string
+		roleErr error
+		want    map[string]bool
+		message map[string]string
+	}{
+		{
+			name: "vendor runs every step",
+			role: adminModel.RoleVendor,
+			want: map[string]bool{
+				cascadeStepHideCategories: true,
+				cascadeStepCancelBookings: true,
+				cascadeStepHoldPayouts:    true,
+			},
+			message: map[string]string{
+				cascadeStepHideCategories: "2 category memberships hidden",
+				cascadeStepCancelBookings: "2 bookings cancelled, 1 refunded for 100 in total",
+			},
+		},
+		{
+			name: "client skips vendor steps",
+			role: adminModel.RoleClient,
+			want: map[string]bool{
+				cascadeStepHideCategories: true,
+				cascadeStepCancelBookings: true,
+				cascadeStepHoldPayouts:    true,
+			},
+			message: map[string]string{
+				cascadeStepHideCategories: "skipped, user is not a vendor",
+				cascadeStepCancelBookings: "skipped, user is not a vendor",
+			},
+		},
+		{
+			name:    "role lookup failure fails vendor steps",
+			roleErr: errors.New("connection refused"),
+			want: map[string]bool{
+				cascadeStepHideCategories: false,
+				cascadeStepCancelBookings: false,
+				cascadeStepHoldPayouts:    true,
+			},
+			message: map[string]string{
+				cascadeStepHideCategories: "failed to look up user role",
+				cascadeStepCancelBookings: "failed to look up user role",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &stubRepo{
+				getUserRole: func(context.Context, string) (string, error) { return tt.role, tt.roleErr },
+				hideVendorCategories: func(context.Context, string, string) (int64, error) {
+					return 2, nil
+				},
+				cancelUpcomingVendorBookings: func(context.Context, string, string) ([]adminModel.Booking, error) {
+					return []adminModel.Booking{
+						{Price: 100, Refunded: true},
+						{Price: 40},
+					}, nil
+				},
+				holdPayouts: func(context.Context, string, string) (int64, error) { return 0, nil },
+			}
+
+			steps := newTestService(repo).runBlockCascade(context.Background(), "user-1", fullPolicy)
+			if len(steps) != len(tt.want) {
+				t.Fatalf("got %d steps, want %d", len(steps), len(tt.want))
+			}
+
+			for _, step := range steps {
+				if step.Success != tt.want[step.Step] {
+					t.Errorf("step %s success = %v, want %v (%s)", step.Step, step.Success, tt.want[step.Step], step.Message)
+				}
+				if msg, ok := tt.message[step.Step]; ok && !strings.HasPrefix(step.Message, msg) {
+					t.Errorf("step %s message = %q, want prefix %q", step.Step, step.Message, msg)
+				}
+			}
+		})
+	}
+}
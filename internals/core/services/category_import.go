@@ -0,0 +1,428 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	pb "github.com/AthulKrishna2501/proto-repo/admin"
+	adminModel "github.com/AthulKrishna2501/zyra-admin-service/internals/core/models"
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	formatCSV  = "csv"
+	formatJSON = "json"
+
+	maxCategoryImportRows = 5000
+
+	importStatusCreated = "created"
+	importStatusValid   = "valid"
+	importStatusInvalid = "invalid"
+	importStatusSkipped = "skipped"
+)
+
+var categoryFileColumns = []string{
+	"name", "slug", "parent_slug", "description", "icon_url", "display_order", "is_active", "commission_rate",
+}
+
+// ImportCategories validates a CSV or JSON catalogue and, unless it is a dry
+// run, creates all of its categories in one transaction. A single invalid
+// row aborts the whole import so a market is never seeded half way; every
+// row is reported back with its outcome.
+func (s *AdminService) ImportCategories(ctx context.Context, req *pb.ImportCategoriesRequest) (*pb.ImportCategoriesResponse, error) {
+	rows, err := parseCategoryFile(strings.ToLower(req.Format), req.Data)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "Failed to parse import file: %v", err)
+	}
+
+	if len(rows) == 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "Import file contains no categories")
+	}
+	if len(rows) > maxCategoryImportRows {
+		return nil, status.Errorf(codes.InvalidArgument, "Import file has %d rows, the limit is %d", len(rows), maxCategoryImportRows)
+	}
+
+	existing, err := s.AdminRepo.ListCategories(ctx, false)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Failed to fetch categories: %v", err)
+	}
+
+	ordered, created := validateCategoryImport(rows, existing)
+
+	resp := &pb.ImportCategoriesResponse{DryRun: req.DryRun}
+	for _, row := range rows {
+		if len(row.Errors) > 0 {
+			resp.Failed++
+		}
+	}
+
+	if resp.Failed == 0 && !req.DryRun {
+		if err := s.AdminRepo.CreateCategories(ctx, ordered); err != nil {
+			return nil, status.Errorf(codes.Aborted, "Import failed, no categories were created: %v", err)
+		}
+		resp.Created = int32(len(ordered))
+	}
+
+	for _, row := range rows {
+		result := &pb.CategoryImportResult{
+			Row:  int32(row.Row),
+			Name: row.Name,
+			Slug: row.Slug,
+		}
+
+		switch {
+		case len(row.Errors) > 0:
+			result.Status = importStatusInvalid
+			result.Message = strings.Join(row.Errors, "; ")
+		case req.DryRun:
+			result.Status = importStatusValid
+		case resp.Failed > 0:
+			result.Status = importStatusSkipped
+			result.Message = "import aborted because other rows are invalid"
+		default:
+			result.Status = importStatusCreated
+			result.CategoryId = created[row].CategoryID.String()
+		}
+
+		resp.Results = append(resp.Results, result)
+	}
+
+	s.log.Info("Admin Service: category import finished", req.DryRun, resp.Created, resp.Failed)
+
+	return resp, nil
+}
+
+func (s *AdminService) ExportCategories(ctx context.Context, req *pb.ExportCategoriesRequest) (*pb.ExportCategoriesResponse, error) {
+	format := strings.ToLower(req.Format)
+	if format == "" {
+		format = formatCSV
+	}
+	if format != formatCSV && format != formatJSON {
+		return nil, status.Errorf(codes.InvalidArgument, "Invalid format. Allowed values: 'csv', 'json'")
+	}
+
+	categories, err := s.AdminRepo.ListCategories(ctx, false)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Failed to fetch categories: %v", err)
+	}
+
+	// Categories created before slugs existed get a stable stand-in so the
+	// export can still express the hierarchy.
+	slugs := make(map[uuid.UUID]string, len(categories))
+	for _, cat := range categories {
+		slug := cat.Slug
+		if slug == "" {
+			slug = fmt.Sprintf("%s-%s", slugify(cat.CategoryName), cat.CategoryID.String()[:8])
+		}
+		slugs[cat.CategoryID] = slug
+	}
+
+	var rows []adminModel.CategoryImportRow
+	for _, cat := range categories {
+		isActive := cat.IsActive
+		row := adminModel.CategoryImportRow{
+			Name:           cat.CategoryName,
+			Slug:           slugs[cat.CategoryID],
+			Description:    cat.Description,
+			IconURL:        cat.IconURL,
+			DisplayOrder:   cat.DisplayOrder,
+			IsActive:       &isActive,
+			CommissionRate: cat.CommissionRate,
+		}
+		if cat.ParentID != nil {
+			row.ParentSlug = slugs[*cat.ParentID]
+		}
+		rows = append(rows, row)
+	}
+
+	data, err := encodeCategoryFile(format, rows)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Failed to encode categories: %v", err)
+	}
+
+	return &pb.ExportCategoriesResponse{
+		FileName:    fmt.Sprintf("categories-%s.%s", time.Now().UTC().Format("20060102"), format),
+		ContentType: contentTypeFor(format),
+		Data:        data,
+	}, nil
+}
+
+// validateCategoryImport records every problem on the offending row and,
+// when the file is clean, returns the categories to create ordered so that
+// parents precede their children, together with the category built for
+// each row.
+func validateCategoryImport(rows []*adminModel.CategoryImportRow, existing []adminModel.Category) ([]*adminModel.Category, map[*adminModel.CategoryImportRow]*adminModel.Category) {
+	existingBySlug := make(map[string]adminModel.Category)
+	existingNames := make(map[string]bool)
+	for _, cat := range existing {
+		if cat.Slug != "" {
+			existingBySlug[cat.Slug] = cat
+		}
+		existingNames[siblingKey(cat.ParentID, cat.CategoryName)] = true
+	}
+
+	rowsBySlug := make(map[string]*adminModel.CategoryImportRow)
+	for _, row := range rows {
+		if row.Name == "" {
+			row.Errors = append(row.Errors, "name is required")
+		}
+
+		if row.Slug == "" {
+			row.Slug = slugify(row.Name)
+		}
+		if !slugPattern.MatchString(row.Slug) {
+			row.Errors = append(row.Errors, fmt.Sprintf("invalid slug %q", row.Slug))
+		} else if first, ok := rowsBySlug[row.Slug]; ok {
+			row.Errors = append(row.Errors, fmt.Sprintf("slug %q is also used on row %d", row.Slug, first.Row))
+		} else {
+			rowsBySlug[row.Slug] = row
+		}
+		if _, ok := existingBySlug[row.Slug]; ok {
+			row.Errors = append(row.Errors, fmt.Sprintf("slug %q already exists", row.Slug))
+		}
+
+		if row.IconURL != "" && !isHTTPURL(row.IconURL) {
+			row.Errors = append(row.Errors, "icon_url must be an absolute http(s) URL")
+		}
+		if row.CommissionRate < 0 || row.CommissionRate > 100 {
+			row.Errors = append(row.Errors, "commission_rate must be between 0 and 100")
+		}
+	}
+
+	created := make(map[*adminModel.CategoryImportRow]*adminModel.Category, len(rows))
+	fileNames := make(map[string]int)
+	for _, row := range rows {
+		category := &adminModel.Category{
+			CategoryID:     uuid.New(),
+			CategoryName:   row.Name,
+			Description:    row.Description,
+			IconURL:        row.IconURL,
+			DisplayOrder:   row.DisplayOrder,
+			IsActive:       row.IsActive == nil || *row.IsActive,
+			Slug:           row.Slug,
+			CommissionRate: row.CommissionRate,
+		}
+		created[row] = category
+
+		nameKey := ""
+		switch {
+		case row.ParentSlug == "":
+			nameKey = siblingKey(nil, row.Name)
+		case row.ParentSlug == row.Slug:
+			row.Errors = append(row.Errors, "a category cannot be its own parent")
+		case rowsBySlug[row.ParentSlug] != nil:
+			nameKey = "file:" + row.ParentSlug + "\x00" + strings.ToLower(row.Name)
+		default:
+			parent, ok := existingBySlug[row.ParentSlug]
+			if !ok {
+				row.Errors = append(row.Errors, fmt.Sprintf("unknown parent slug %q", row.ParentSlug))
+				break
+			}
+			category.ParentID = &parent.CategoryID
+			nameKey = siblingKey(category.ParentID, row.Name)
+		}
+
+		if nameKey == "" || row.Name == "" {
+			continue
+		}
+		if existingNames[nameKey] {
+			row.Errors = append(row.Errors, fmt.Sprintf("category %q already exists under the same parent", row.Name))
+		} else if first, ok := fileNames[nameKey]; ok {
+			row.Errors = append(row.Errors, fmt.Sprintf("category %q is also defined on row %d under the same parent", row.Name, first))
+		} else {
+			fileNames[nameKey] = row.Row
+		}
+	}
+
+	const (
+		unvisited = iota
+		visiting
+		done
+	)
+	state := make(map[*adminModel.CategoryImportRow]int, len(rows))
+	var ordered []*adminModel.Category
+
+	var visit func(row *adminModel.CategoryImportRow) bool
+	visit = func(row *adminModel.CategoryImportRow) bool {
+		switch state[row] {
+		case done:
+			return len(row.Errors) == 0
+		case visiting:
+			row.Errors = append(row.Errors, "parent chain forms a cycle")
+			return false
+		}
+
+		state[row] = visiting
+		if parent := rowsBySlug[row.ParentSlug]; parent != nil && row.ParentSlug != row.Slug {
+			if !visit(parent) {
+				row.Errors = append(row.Errors, fmt.Sprintf("parent row %d is invalid", parent.Row))
+			} else {
+				created[row].ParentID = &created[parent].CategoryID
+			}
+		}
+		state[row] = done
+
+		if len(row.Errors) > 0 {
+			return false
+		}
+		ordered = append(ordered, created[row])
+		return true
+	}
+
+	for _, row := range rows {
+		visit(row)
+	}
+
+	return ordered, created
+}
+
+func siblingKey(parentID *uuid.UUID, name string) string {
+	name = strings.ToLower(name)
+	if parentID == nil {
+		return "root\x00" + name
+	}
+	return "id:" + parentID.String() + "\x00" + name
+}
+
+func parseCategoryFile(format string, data []byte) ([]*adminModel.CategoryImportRow, error) {
+	var rows []*adminModel.CategoryImportRow
+
+	switch format {
+	case formatJSON:
+		if err := json.Unmarshal(data, &rows); err != nil {
+			return nil, err
+		}
+		for i, row := range rows {
+			if row == nil {
+				return nil, fmt.Errorf("row %d is not an object", i+1)
+			}
+			row.Row = i + 1
+			trimImportRow(row)
+		}
+
+	case formatCSV:
+		reader := csv.NewReader(bytes.NewReader(data))
+		reader.TrimLeadingSpace = true
+		records, err := reader.ReadAll()
+		if err != nil {
+			return nil, err
+		}
+		if len(records) == 0 {
+			return nil, nil
+		}
+
+		columns := make(map[string]int)
+		for i, name := range records[0] {
+			columns[strings.ToLower(strings.TrimSpace(name))] = i
+		}
+		if _, ok := columns["name"]; !ok {
+			return nil, fmt.Errorf("header row must contain a name column")
+		}
+
+		for i, record := range records[1:] {
+			field := func(name string) string {
+				if idx, ok := columns[name]; ok && idx < len(record) {
+					return strings.TrimSpace(record[idx])
+				}
+				return ""
+			}
+
+			row := &adminModel.CategoryImportRow{
+				Row:         i + 2,
+				Name:        field("name"),
+				Slug:        field("slug"),
+				ParentSlug:  field("parent_slug"),
+				Description: field("description"),
+				IconURL:     field("icon_url"),
+			}
+
+			if v := field("display_order"); v != "" {
+				order, err := strconv.Atoi(v)
+				if err != nil {
+					row.Errors = append(row.Errors, fmt.Sprintf("invalid display_order %q", v))
+				}
+				row.DisplayOrder = order
+			}
+			if v := field("is_active"); v != "" {
+				active, err := strconv.ParseBool(v)
+				if err != nil {
+					row.Errors = append(row.Errors, fmt.Sprintf("invalid is_active %q", v))
+				}
+				row.IsActive = &active
+			}
+			if v := field("commission_rate"); v != "" {
+				rate, err := strconv.ParseFloat(v, 64)
+				if err != nil {
+					row.Errors = append(row.Errors, fmt.Sprintf("invalid commission_rate %q", v))
+				}
+				row.CommissionRate = rate
+			}
+
+			trimImportRow(row)
+			rows = append(rows, row)
+		}
+
+	default:
+		return nil, fmt.Errorf("invalid format %q, allowed values: 'csv', 'json'", format)
+	}
+
+	return rows, nil
+}
+
+func trimImportRow(row *adminModel.CategoryImportRow) {
+	row.Name = adminModel.NormalizeCategoryName(row.Name)
+	row.Slug = strings.TrimSpace(row.Slug)
+	row.ParentSlug = strings.TrimSpace(row.ParentSlug)
+	row.Description = strings.TrimSpace(row.Description)
+	row.IconURL = strings.TrimSpace(row.IconURL)
+}
+
+func encodeCategoryFile(format string, rows []adminModel.CategoryImportRow) ([]byte, error) {
+	if format == formatJSON {
+		if rows == nil {
+			rows = []adminModel.CategoryImportRow{}
+		}
+		return json.MarshalIndent(rows, "", "  ")
+	}
+
+	var buf bytes.Buffer
+	writer := csv.NewWriter(&buf)
+	if err := writer.Write(categoryFileColumns); err != nil {
+		return nil, err
+	}
+
+	for _, row := range rows {
+		isActive := row.IsActive == nil || *row.IsActive
+		record := []string{
+			row.Name,
+			row.Slug,
+			row.ParentSlug,
+			row.Description,
+			row.IconURL,
+			strconv.Itoa(row.DisplayOrder),
+			strconv.FormatBool(isActive),
+			strconv.FormatFloat(row.CommissionRate, 'f', -1, 64),
+		}
+		if err := writer.Write(record); err != nil {
+			return nil, err
+		}
+	}
+
+	writer.Flush()
+	return buf.Bytes(), writer.Error()
+}
+
+func contentTypeFor(format string) string {
+	if format == formatJSON {
+		return "application/json"
+	}
+	return "text/csv"
+}
@@ -0,0 +1,242 @@
+package services
+
+import (
+	"reflect"
+	"testing"
+
+	adminModel "github.com/AthulKrishna2501/zyra-admin-service/internals/core/models"
+	"github.com/google/uuid"
+)
+
+func TestParseCategoryFile(t *testing.T) {
+	active := true
+
+	tests := []struct {
+		name    string
+		format  string
+		data    string
+		want    []*adminModel.CategoryImportRow
+		wantErr bool
+	}{
+		{
+			name:   "csv normalizes names",
+			format: formatCSV,
+			data:   "name,slug,parent_slug,is_active\n\"  Wedding   Photography \", wedding-photography ,,true\n",
+			want: []*adminModel.CategoryImportRow{
+				{Row: 2, Name: "Wedding Photography", Slug: "wedding-photography", IsActive: &active},
+			},
+		},
+		{
+			name:   "json normalizes names",
+			format: formatJSON,
+			data:   `[{"name": " Live \t Music ", "parent_slug": " events "}]`,
+			want: []*adminModel.CategoryImportRow{
+				{Row: 1, Name: "Live Music", ParentSlug: "events"},
+			},
+		},
+		{
+			name:   "csv header is case insensitive",
+			format: formatCSV,
+			data:   " Name ,Display_Order\nCatering,3\n",
+			want: []*adminModel.CategoryImportRow{
+				{Row: 2, Name: "Catering", DisplayOrder: 3},
+			},
+		},
+		{
+			name:   "csv bad values are row errors",
+			format: formatCSV,
+			data:   "name,display_order,commission_rate\nCatering,first,ten\n",
+			want: []*adminModel.CategoryImportRow{
+				{Row: 2, Name: "Catering", Errors: []string{`invalid display_order "first"`, `invalid commission_rate "ten"`}},
+			},
+		},
+		{
+			name:    "csv without name column",
+			format:  formatCSV,
+			data:    "slug\ncatering\n",
+			wantErr: true,
+		},
+		{
+			name:    "json null row",
+			format:  formatJSON,
+			data:    `[null]`,
+			wantErr: true,
+		},
+		{
+			name:    "unknown format",
+			format:  "xml",
+			data:    "<categories/>",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseCategoryFile(tt.format, []byte(tt.data))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseCategoryFile() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parseCategoryFile() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidateCategoryImport(t *testing.T) {
+	eventsID := uuid.New()
+	existing := []adminModel.Category{
+		{CategoryID: eventsID, CategoryName: "Events", Slug: "events"},
+		{CategoryID: uuid.New(), CategoryName: "Live Music", Slug: "live-music", ParentID: &eventsID},
+	}
+
+	tests := []struct {
+		name string
+		rows []*adminModel.CategoryImportRow
+		// want lists, per row, whether it should come back with errors.
+		want []bool
+		// order is the slugs to create, parents first, when the file is clean.
+		order []string
+	}{
+		{
+			name: "children listed before parents",
+			rows: []*adminModel.CategoryImportRow{
+				{Row: 2, Name: "Drone", ParentSlug: "wedding-photography"},
+				{Row: 3, Name: "Wedding Photography", ParentSlug: "photography"},
+				{Row: 4, Name: "Photography"},
+			},
+			want:  []bool{false, false, false},
+			order: []string{"photography", "wedding-photography", "drone"},
+		},
+		{
+			name: "existing parent",
+			rows: []*adminModel.CategoryImportRow{
+				{Row: 2, Name: "DJ", ParentSlug: "events"},
+			},
+			want:  []bool{false},
+			order: []string{"dj"},
+		},
+		{
+			name: "name taken under existing parent",
+			rows: []*adminModel.CategoryImportRow{
+				{Row: 2, Name: "live music", Slug: "live-music-2", ParentSlug: "events"},
+			},
+			want: []bool{true},
+		},
+		{
+			name: "duplicate slug in file",
+			rows: []*adminModel.CategoryImportRow{
+				{Row: 2, Name: "Catering"},
+				{Row: 3, Name: "Catering Services", Slug: "catering"},
+			},
+			want: []bool{false, true},
+		},
+		{
+			name: "slug already exists",
+			rows: []*adminModel.CategoryImportRow{
+				{Row: 2, Name: "Gatherings", Slug: "events"},
+			},
+			want: []bool{true},
+		},
+		{
+			name: "bad fields",
+			rows: []*adminModel.CategoryImportRow{
+				{Row: 2, Name: ""},
+				{Row: 3, Name: "Catering", IconURL: "/icons/catering.png"},
+				{Row: 4, Name: "Decor", CommissionRate: 120},
+				{Row: 5, Name: "Venues", ParentSlug: "nowhere"},
+			},
+			want: []bool{true, true, true, true},
+		},
+		{
+			name: "own parent",
+			rows: []*adminModel.CategoryImportRow{
+				{Row: 2, Name: "Catering", ParentSlug: "catering"},
+			},
+			want: []bool{true},
+		},
+		{
+			name: "cycle",
+			rows: []*adminModel.CategoryImportRow{
+				{Row: 2, Name: "A", ParentSlug: "b"},
+				{Row: 3, Name: "B", ParentSlug: "a"},
+			},
+			want: []bool{true, true},
+		},
+		{
+			name: "child of invalid row",
+			rows: []*adminModel.CategoryImportRow{
+				{Row: 2, Name: "Decor", CommissionRate: -1},
+				{Row: 3, Name: "Flowers", ParentSlug: "decor"},
+			},
+			want: []bool{true, true},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ordered, created := validateCategoryImport(tt.rows, existing)
+
+			clean := true
+			for i, row := range tt.rows {
+				if got := len(row.Errors) > 0; got != tt.want[i] {
+					t.Errorf("row %d errors = %v, want errors %v", row.Row, row.Errors, tt.want[i])
+				}
+				if created[row] == nil {
+					t.Errorf("row %d has no category", row.Row)
+				}
+				clean = clean && len(row.Errors) == 0
+			}
+			if !clean {
+				return
+			}
+
+			var slugs []string
+			for _, category := range ordered {
+				slugs = append(slugs, category.Slug)
+			}
+			if !reflect.DeepEqual(slugs, tt.order) {
+				t.Errorf("creation order = %v, want %v", slugs, tt.order)
+			}
+		})
+	}
+}
+
+func TestEncodeCategoryFile(t *testing.T) {
+	inactive := false
+	rows := []adminModel.CategoryImportRow{
+		{Name: "Photography", Slug: "photography", CommissionRate: 12.5},
+		{Name: "Wedding, Photography", Slug: "wedding-photography", ParentSlug: "photography",
+			Description: "Ceremonies \"and\" receptions", DisplayOrder: 2, IsActive: &inactive},
+	}
+
+	for _, format := range []string{formatCSV, formatJSON} {
+		t.Run(format, func(t *testing.T) {
+			data, err := encodeCategoryFile(format, rows)
+			if err != nil {
+				t.Fatalf("encodeCategoryFile() error = %v", err)
+			}
+
+			parsed, err := parseCategoryFile(format, data)
+			if err != nil {
+				t.Fatalf("parseCategoryFile() error = %v", err)
+			}
+
+			got := make([]adminModel.CategoryImportRow, 0, len(parsed))
+			for _, row := range parsed {
+				row.Row = 0
+				if row.IsActive != nil && *row.IsActive {
+					row.IsActive = nil
+				}
+				got = append(got, *row)
+			}
+			if !reflect.DeepEqual(got, rows) {
+				t.Errorf("round trip = %+v, want %+v", got, rows)
+			}
+		})
+	}
+
+	if got, _ := encodeCategoryFile(formatJSON, nil); string(got) != "[]" {
+		t.Errorf("encodeCategoryFile(json, nil) = %s, want []", got)
+	}
+}
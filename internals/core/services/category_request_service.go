@@ -0,0 +1,350 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	pb "github.com/AthulKrishna2501/proto-repo/admin"
+	adminModel "github.com/AthulKrishna2501/zyra-admin-service/internals/core/models"
+	"github.com/AthulKrishna2501/zyra-admin-service/internals/core/repository"
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"gorm.io/gorm"
+)
+
+// A category request is due soon after a day in the queue and overdue after
+// two.
+const (
+	categoryRequestDueSoon = 24 * time.Hour
+	categoryRequestOverdue = 48 * time.Hour
+)
+
+const maxBulkCategoryRequests = 200
+
+// legacyRejectionNotes is recorded on rejections from callers that do not
+// send a reason code yet.
+const legacyRejectionNotes = "No reason given"
+
+func (s *AdminService) AssignCategoryRequest(ctx context.Context, req *pb.AssignCategoryRequestRequest) (*pb.AssignCategoryRequestResponse, error) {
+	if _, err := uuid.Parse(req.RequestId); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "Invalid request ID: %v", err)
+	}
+
+	assignee := strings.TrimSpace(req.AssignedTo)
+	request, err := s.AdminRepo.AssignCategoryRequest(ctx, req.RequestId, assignee, req.Reassign)
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		return nil, status.Errorf(codes.NotFound, "Category request %s not found", req.RequestId)
+	case errors.Is(err, repository.ErrRequestAlreadyDecided):
+		return nil, status.Errorf(codes.FailedPrecondition, "Category request %s has already been decided", req.RequestId)
+	case errors.Is(err, repository.ErrRequestAssigned):
+		return nil, status.Errorf(codes.FailedPrecondition, "Category request %s is already assigned to another admin, set reassign to take it over", req.RequestId)
+	case err != nil:
+		return nil, status.Errorf(codes.Internal, "Failed to assign category request: %v", err)
+	}
+
+	s.log.Info("Admin Service: category request assigned", req.RequestId, assignee)
+
+	message := "category request assigned"
+	if assignee == "" {
+		message = "category request unassigned"
+	}
+
+	return &pb.AssignCategoryRequestResponse{
+		RequestId:  request.RequestID.String(),
+		AssignedTo: request.AssignedTo,
+		Message:    message,
+	}, nil
+}
+
+// GetVendorCategoryDecisions lets the vendor service show a vendor what
+// happened to their category requests, including why a request was
+// rejected.
+func (s *AdminService) GetVendorCategoryDecisions(ctx context.Context, req *pb.GetVendorCategoryDecisionsRequest) (*pb.GetVendorCategoryDecisionsResponse, error) {
+	if _, err := uuid.Parse(req.VendorId); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "Invalid vendor ID: %v", err)
+	}
+	if req.CategoryId != "" {
+		if _, err := uuid.Parse(req.CategoryId); err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "Invalid category ID: %v", err)
+		}
+	}
+
+	requests, err := s.AdminRepo.GetVendorCategoryRequests(ctx, req.VendorId, req.CategoryId)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Failed to fetch category requests: %v", err)
+	}
+
+	pbRequests, err := s.toPbCategoryRequests(ctx, requests, true)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.GetVendorCategoryDecisionsResponse{
+		Requests: pbRequests,
+	}, nil
+}
+
+func (s *AdminService) ListRejectionReasons(ctx context.Context, req *pb.ListRejectionReasonsRequest) (*pb.ListRejectionReasonsResponse, error) {
+	var reasons []*pb.RejectionReason
+	for _, reason := range adminModel.RejectionReasons {
+		reasons = append(reasons, &pb.RejectionReason{
+			Code:  reason.Code,
+			Label: reason.Label,
+		})
+	}
+
+	return &pb.ListRejectionReasonsResponse{Reasons: reasons}, nil
+}
+
+// BulkDecideCategoryRequests applies one decision to many requests. Each
+// request is decided in its own transaction, so one failure does not undo
+// or block the others, and every request gets its own outcome.
+func (s *AdminService) BulkDecideCategoryRequests(ctx context.Context, req *pb.BulkDecideCategoryRequestsRequest) (*pb.BulkDecideCategoryRequestsResponse, error) {
+	if len(req.RequestIds) == 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "At least one request ID is required")
+	}
+
+	var requestIDs []string
+	seen := make(map[string]bool)
+	for _, id := range req.RequestIds {
+		if !seen[id] {
+			seen[id] = true
+			requestIDs = append(requestIDs, id)
+		}
+	}
+
+	if len(requestIDs) > maxBulkCategoryRequests {
+		return nil, status.Errorf(codes.InvalidArgument, "Cannot decide more than %d category requests at once, got %d", maxBulkCategoryRequests, len(requestIDs))
+	}
+
+	decision := adminModel.CategoryDecision{
+		Status:     req.Status,
+		ReasonCode: req.ReasonCode,
+		Notes:      strings.TrimSpace(req.Notes),
+		DecidedBy:  req.DecidedBy,
+	}
+	if err := validateCategoryDecision(decision); err != nil {
+		return nil, err
+	}
+
+	resp := &pb.BulkDecideCategoryRequestsResponse{}
+	for _, id := range requestIDs {
+		result := &pb.CategoryDecisionResult{RequestId: id}
+
+		if err := s.decideCategoryRequestByID(ctx, id, decision); err != nil {
+			st := status.Convert(err)
+			result.Code = st.Code().String()
+			result.Message = st.Message()
+			resp.Failed++
+		} else {
+			result.Success = true
+			result.Code = codes.OK.String()
+			result.Message = fmt.Sprintf("category request has been %s", decision.Status)
+			resp.Succeeded++
+		}
+
+		resp.Results = append(resp.Results, result)
+	}
+
+	s.log.Info("Admin Service: bulk category decision", decision.Status, decision.DecidedBy, resp.Succeeded, resp.Failed)
+
+	return resp, nil
+}
+
+func (s *AdminService) decideCategoryRequestByID(ctx context.Context, requestID string, decision adminModel.CategoryDecision) error {
+	if _, err := uuid.Parse(requestID); err != nil {
+		return status.Errorf(codes.InvalidArgument, "Invalid request ID: %v", err)
+	}
+
+	request, err := s.AdminRepo.GetCategoryRequest(ctx, requestID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return status.Errorf(codes.NotFound, "Category request %s not found", requestID)
+	} else if err != nil {
+		return status.Errorf(codes.Internal, "Failed to fetch category request: %v", err)
+	}
+
+	return s.applyCategoryDecision(ctx, request, decision)
+}
+
+func (s *AdminService) toPbCategoryRequests(ctx context.Context, requests []adminModel.CategoryRequest, includeHistory bool) ([]*pb.CategoryRequest, error) {
+	history := make(map[uuid.UUID][]*pb.CategoryRequestDecision)
+	if includeHistory {
+		requestIDs := make([]uuid.UUID, 0, len(requests))
+		for _, r := range requests {
+			requestIDs = append(requestIDs, r.RequestID)
+		}
+
+		decisions, err := s.AdminRepo.GetCategoryRequestDecisions(ctx, requestIDs)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "Failed to fetch decision history: %v", err)
+		}
+
+		for _, d := range decisions {
+			history[d.RequestID] = append(history[d.RequestID], &pb.CategoryRequestDecision{
+				Status:      d.Status,
+				ReasonCode:  d.ReasonCode,
+				ReasonLabel: rejectionLabel(d.ReasonCode),
+				Notes:       d.Notes,
+				DecidedBy:   d.DecidedBy,
+				DecidedAt:   timestamppb.New(d.CreatedAt),
+			})
+		}
+	}
+
+	var pbRequests []*pb.CategoryRequest
+	for _, r := range requests {
+		pbRequest := &pb.CategoryRequest{
+			RequestId:   r.RequestID.String(),
+			VendorId:    r.VendorID.String(),
+			CategoryId:  r.CategoryID.String(),
+			Name:        r.CategoryName,
+			VendorName:  r.VendorName,
+			Date:        r.CreatedAt.String(),
+			Status:      r.Status,
+			DecidedBy:   r.DecidedBy,
+			ReasonCode:  r.ReasonCode,
+			ReasonLabel: rejectionLabel(r.ReasonCode),
+			Notes:       r.Notes,
+			AssignedTo:  r.AssignedTo,
+			AgeHours:    int32(requestAge(r).Hours()),
+			AgeBucket:   ageBucket(requestAge(r)),
+			History:     history[r.RequestID],
+		}
+		if r.DecidedAt != nil {
+			pbRequest.DecidedAt = timestamppb.New(*r.DecidedAt)
+		}
+		if r.AssignedAt != nil {
+			pbRequest.AssignedAt = timestamppb.New(*r.AssignedAt)
+		}
+
+		pbRequests = append(pbRequests, pbRequest)
+	}
+
+	return pbRequests, nil
+}
+
+func categoryRequestFilter(req *pb.ViewRequestsReq, requestStatus string) (adminModel.CategoryRequestFilter, error) {
+	filter := adminModel.CategoryRequestFilter{
+		Status:     requestStatus,
+		CategoryID: req.CategoryId,
+		VendorID:   req.VendorId,
+		AssignedTo: strings.TrimSpace(req.AssignedTo),
+		Unassigned: req.Unassigned,
+	}
+
+	if filter.CategoryID != "" {
+		if _, err := uuid.Parse(filter.CategoryID); err != nil {
+			return filter, status.Errorf(codes.InvalidArgument, "Invalid category ID: %v", err)
+		}
+	}
+	if filter.VendorID != "" {
+		if _, err := uuid.Parse(filter.VendorID); err != nil {
+			return filter, status.Errorf(codes.InvalidArgument, "Invalid vendor ID: %v", err)
+		}
+	}
+	if filter.AssignedTo != "" && filter.Unassigned {
+		return filter, status.Errorf(codes.InvalidArgument, "AssignedTo and Unassigned cannot be combined")
+	}
+
+	switch req.Sort {
+	case "", "oldest":
+	case "newest":
+		filter.NewestFirst = true
+	default:
+		return filter, status.Errorf(codes.InvalidArgument, "Invalid sort. Allowed values: 'oldest', 'newest'")
+	}
+
+	filter.Limit, filter.Offset = pageBounds(req.Page, req.PageSize)
+
+	return filter, nil
+}
+
+// requestAge is how long a request has waited: until now while it is pending
+// and until its decision afterwards.
+func requestAge(r adminModel.CategoryRequest) time.Duration {
+	if r.DecidedAt != nil {
+		return r.DecidedAt.Sub(r.CreatedAt)
+	}
+	return time.Since(r.CreatedAt)
+}
+
+func ageBucket(age time.Duration) string {
+	switch {
+	case age < categoryRequestDueSoon:
+		return adminModel.SLABucketOnTrack
+	case age < categoryRequestOverdue:
+		return adminModel.SLABucketDueSoon
+	default:
+		return adminModel.SLABucketOverdue
+	}
+}
+
+// validateCategoryDecision requires a known reason code for rejections, and
+// notes when the reason is "other". Approvals may carry notes but no reason.
+func validateCategoryDecision(decision adminModel.CategoryDecision) error {
+	if strings.TrimSpace(decision.DecidedBy) == "" {
+		return status.Errorf(codes.InvalidArgument, "DecidedBy is required")
+	}
+
+	if decision.Status != adminModel.CategoryRequestApproved && decision.Status != adminModel.CategoryRequestRejected {
+		return status.Errorf(codes.InvalidArgument, "Invalid status. Allowed values: 'approved', 'rejected'")
+	}
+
+	if decision.Status == adminModel.CategoryRequestApproved {
+		if decision.ReasonCode != "" {
+			return status.Errorf(codes.InvalidArgument, "A reason code can only be given when rejecting")
+		}
+		return nil
+	}
+
+	if decision.ReasonCode == "" {
+		return status.Errorf(codes.InvalidArgument, "A reason code is required when rejecting a category request")
+	}
+
+	if rejectionLabel(decision.ReasonCode) == "" {
+		allowed := make([]string, 0, len(adminModel.RejectionReasons))
+		for _, reason := range adminModel.RejectionReasons {
+			allowed = append(allowed, reason.Code)
+		}
+		return status.Errorf(codes.InvalidArgument, "Invalid reason code %q. Allowed values: %s", decision.ReasonCode, strings.Join(allowed, ", "))
+	}
+
+	if decision.ReasonCode == adminModel.RejectionOther && strings.TrimSpace(decision.Notes) == "" {
+		return status.Errorf(codes.InvalidArgument, "Notes are required when the reason is 'other'")
+	}
+
+	return nil
+}
+
+// legacyCategoryDecision fills in what ApproveRejectCategory callers that
+// still identify a request by vendor and category do not send: a missing
+// decider is recorded as unknown and a rejection without a reason code as
+// "other".
+func legacyCategoryDecision(decision adminModel.CategoryDecision) adminModel.CategoryDecision {
+	if strings.TrimSpace(decision.DecidedBy) == "" {
+		decision.DecidedBy = adminModel.UnknownDecider
+	}
+	if decision.Status == adminModel.CategoryRequestRejected && decision.ReasonCode == "" {
+		decision.ReasonCode = adminModel.RejectionOther
+		if decision.Notes == "" {
+			decision.Notes = legacyRejectionNotes
+		}
+	}
+
+	return decision
+}
+
+func rejectionLabel(code string) string {
+	for _, reason := range adminModel.RejectionReasons {
+		if reason.Code == code {
+			return reason.Label
+		}
+	}
+	return ""
+}
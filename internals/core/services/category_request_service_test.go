@@ -0,0 +1,495 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	pb "github.com/AthulKrishna2501/proto-repo/admin"
+	adminModel "github.com/AthulKrishna2501/zyra-admin-service/internals/core/models"
+	"github.com/AthulKrishna2501/zyra-admin-service/internals/core/repository"
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"gorm.io/gorm"
+)
+
+func TestValidateCategoryDecision(t *testing.T) {
+	tests := []struct {
+		name     string
+		decision adminModel.CategoryDecision
+		want     codes.Code
+	}{
+		{
+			name:     "approval",
+			decision: adminModel.CategoryDecision{Status: adminModel.CategoryRequestApproved, DecidedBy: "admin@zyra.com"},
+			want:     codes.OK,
+		},
+		{
+			name:     "missing decider",
+			decision: adminModel.CategoryDecision{Status: adminModel.CategoryRequestApproved, DecidedBy: "  "},
+			want:     codes.InvalidArgument,
+		},
+		{
+			name:     "unknown status",
+			decision: adminModel.CategoryDecision{Status: "pending", DecidedBy: "admin@zyra.com"},
+			want:     codes.InvalidArgument,
+		},
+		{
+			name: "approval with reason code",
+			decision: adminModel.CategoryDecision{Status: adminModel.CategoryRequestApproved, DecidedBy: "admin@zyra.com",
+				ReasonCode: adminModel.RejectionPolicyViolation},
+			want: codes.InvalidArgument,
+		},
+		{
+			name:     "rejection without reason code",
+			decision: adminModel.CategoryDecision{Status: adminModel.CategoryRequestRejected, DecidedBy: "admin@zyra.com"},
+			want:     codes.InvalidArgument,
+		},
+		{
+			name: "rejection with unknown reason code",
+			decision: adminModel.CategoryDecision{Status: adminModel.CategoryRequestRejected, DecidedBy: "admin@zyra.com",
+				ReasonCode: "too_expensive"},
+			want: codes.InvalidArgument,
+		},
+		{
+			name: "other without notes",
+			decision: adminModel.CategoryDecision{Status: adminModel.CategoryRequestRejected, DecidedBy: "admin@zyra.com",
+				ReasonCode: adminModel.RejectionOther},
+			want: codes.InvalidArgument,
+		},
+		{
+			name: "rejection",
+			decision: adminModel.CategoryDecision{Status: adminModel.CategoryRequestRejected, DecidedBy: "admin@zyra.com",
+				ReasonCode: adminModel.RejectionCategoryMismatch},
+			want: codes.OK,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := status.Code(validateCategoryDecision(tt.decision)); got != tt.want {
+				t.Errorf("validateCategoryDecision() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestApplyCategoryDecisionRepositoryErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want codes.Code
+	}{
+		{"decided", nil, codes.OK},
+		{"decided concurrently", repository.ErrRequestAlreadyDecided, codes.FailedPrecondition},
+		{"assigned to another admin", repository.ErrRequestAssigned, codes.FailedPrecondition},
+		{"database failure", errors.New("connection reset"), codes.Internal},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &stubRepo{
+				decideCategoryRequest: func(context.Context, string, adminModel.CategoryDecision) error { return tt.err },
+			}
+			request := &adminModel.CategoryRequest{RequestID: uuid.New(), Status: adminModel.CategoryRequestPending}
+			decision := adminModel.CategoryDecision{
+				Status:     adminModel.CategoryRequestRejected,
+				ReasonCode: adminModel.RejectionCategoryMismatch,
+				DecidedBy:  "admin@zyra.com",
+			}
+
+			err := newTestService(repo).applyCategoryDecision(context.Background(), request, decision)
+			if got := status.Code(err); got != tt.want {
+				t.Errorf("applyCategoryDecision() = %v, want %v (%v)", got, tt.want, err)
+			}
+		})
+	}
+}
+
+func TestFindCategoryRequest(t *testing.T) {
+	requestID := uuid.New()
+	byID := &adminModel.CategoryRequest{RequestID: requestID}
+	byPair := &adminModel.CategoryRequest{RequestID: uuid.New()}
+
+	tests := []struct {
+		name       string
+		requestID  string
+		lookupErr  error
+		want       *adminModel.CategoryRequest
+		wantStatus codes.Code
+	}{
+		{name: "by request ID", requestID: requestID.String(), want: byID},
+		{name: "oldest pending for the pair", want: byPair},
+		{name: "malformed request ID", requestID: "42", wantStatus: codes.InvalidArgument},
+		{name: "not found", requestID: requestID.String(), lookupErr: gorm.ErrRecordNotFound, wantStatus: codes.NotFound},
+		{name: "database failure", lookupErr: errors.New("connection reset"), wantStatus: codes.Internal},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &stubRepo{
+				getCategoryRequest: func(context.Context, string) (*adminModel.CategoryRequest, error) {
+					if tt.lookupErr != nil {
+						return nil, tt.lookupErr
+					}
+					return byID, nil
+				},
+				findPendingCategoryRequest: func(context.Context, string, string) (*adminModel.CategoryRequest, error) {
+					if tt.lookupErr != nil {
+						return nil, tt.lookupErr
+					}
+					return byPair, nil
+				},
+			}
+
+			got, err := newTestService(repo).findCategoryRequest(context.Background(), tt.requestID, "vendor-1", "category-1")
+			if code := status.Code(err); code != tt.wantStatus {
+				t.Fatalf("findCategoryRequest() = %v, want %v", code, tt.wantStatus)
+			}
+			if got != tt.want {
+				t.Errorf("findCategoryRequest() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestApplyCategoryDecisionRefusesDecidedRequests(t *testing.T) {
+	decision := adminModel.CategoryDecision{Status: adminModel.CategoryRequestApproved, DecidedBy: "admin@zyra.com"}
+
+	for _, current := range []string{adminModel.CategoryRequestApproved, adminModel.CategoryRequestRejected} {
+		t.Run(current, func(t *testing.T) {
+			request := &adminModel.CategoryRequest{RequestID: uuid.New(), Status: current}
+
+			err := newTestService(&stubRepo{}).applyCategoryDecision(context.Background(), request, decision)
+			if got := status.Code(err); got != codes.FailedPrecondition {
+				t.Errorf("applyCategoryDecision() = %v, want %v", got, codes.FailedPrecondition)
+			}
+		})
+	}
+}
+
+func TestRejectionLabel(t *testing.T) {
+	tests := []struct {
+		code string
+		want string
+	}{
+		{adminModel.RejectionCategoryMismatch, "Services offered do not match the category"},
+		{adminModel.RejectionOther, "Other, see notes"},
+		{"too_expensive", ""},
+		{"", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.code, func(t *testing.T) {
+			if got := rejectionLabel(tt.code); got != tt.want {
+				t.Errorf("rejectionLabel(%q) = %q, want %q", tt.code, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestListRejectionReasons(t *testing.T) {
+	resp, err := newTestService(&stubRepo{}).ListRejectionReasons(context.Background(), &pb.ListRejectionReasonsRequest{})
+	if err != nil {
+		t.Fatalf("ListRejectionReasons() error = %v", err)
+	}
+
+	if len(resp.Reasons) != len(adminModel.RejectionReasons) {
+		t.Fatalf("ListRejectionReasons() returned %d reasons, want %d", len(resp.Reasons), len(adminModel.RejectionReasons))
+	}
+
+	seen := make(map[string]bool)
+	for _, reason := range resp.Reasons {
+		if reason.Code == "" || reason.Label == "" {
+			t.Errorf("reason %+v has an empty code or label", reason)
+		}
+		if seen[reason.Code] {
+			t.Errorf("reason code %q is listed twice", reason.Code)
+		}
+		seen[reason.Code] = true
+	}
+}
+
+func TestCategoryRequestFilter(t *testing.T) {
+	const id = "5b0c7a62-2f3e-4b8e-9a55-0f1f0c7d3a11"
+
+	tests := []struct {
+		name       string
+		req        *pb.ViewRequestsReq
+		want       adminModel.CategoryRequestFilter
+		wantStatus codes.Code
+	}{
+		{
+			name: "defaults",
+			req:  &pb.ViewRequestsReq{},
+			want: adminModel.CategoryRequestFilter{Status: adminModel.CategoryRequestPending, Limit: defaultPageSize},
+		},
+		{
+			name: "newest first for an assignee",
+			req:  &pb.ViewRequestsReq{AssignedTo: " admin@zyra.com ", Sort: "newest", Page: 2, PageSize: 10, CategoryId: id},
+			want: adminModel.CategoryRequestFilter{Status: adminModel.CategoryRequestPending, CategoryID: id,
+				AssignedTo: "admin@zyra.com", NewestFirst: true, Limit: 10, Offset: 10},
+		},
+		{
+			name:       "bad category ID",
+			req:        &pb.ViewRequestsReq{CategoryId: "nope"},
+			wantStatus: codes.InvalidArgument,
+		},
+		{
+			name:       "bad vendor ID",
+			req:        &pb.ViewRequestsReq{VendorId: "nope"},
+			wantStatus: codes.InvalidArgument,
+		},
+		{
+			name:       "assigned and unassigned",
+			req:        &pb.ViewRequestsReq{AssignedTo: "admin@zyra.com", Unassigned: true},
+			wantStatus: codes.InvalidArgument,
+		},
+		{
+			name:       "unknown sort",
+			req:        &pb.ViewRequestsReq{Sort: "priority"},
+			wantStatus: codes.InvalidArgument,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := categoryRequestFilter(tt.req, adminModel.CategoryRequestPending)
+			if code := status.Code(err); code != tt.wantStatus {
+				t.Fatalf("categoryRequestFilter() = %v, want %v", code, tt.wantStatus)
+			}
+			if err == nil && got != tt.want {
+				t.Errorf("categoryRequestFilter() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAgeBucket(t *testing.T) {
+	tests := []struct {
+		age  time.Duration
+		want string
+	}{
+		{time.Hour, adminModel.SLABucketOnTrack},
+		{categoryRequestDueSoon - time.Second, adminModel.SLABucketOnTrack},
+		{categoryRequestDueSoon, adminModel.SLABucketDueSoon},
+		{categoryRequestOverdue - time.Second, adminModel.SLABucketDueSoon},
+		{categoryRequestOverdue, adminModel.SLABucketOverdue},
+		{7 * 24 * time.Hour, adminModel.SLABucketOverdue},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.age.String(), func(t *testing.T) {
+			if got := ageBucket(tt.age); got != tt.want {
+				t.Errorf("ageBucket(%v) = %q, want %q", tt.age, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRequestAge(t *testing.T) {
+	created := time.Now().Add(-72 * time.Hour)
+	decided := created.Add(5 * time.Hour)
+
+	if got := requestAge(adminModel.CategoryRequest{CreatedAt: created, DecidedAt: &decided}); got != 5*time.Hour {
+		t.Errorf("requestAge() of a decided request = %v, want %v", got, 5*time.Hour)
+	}
+	if got := requestAge(adminModel.CategoryRequest{CreatedAt: created}); got < 72*time.Hour {
+		t.Errorf("requestAge() of a pending request = %v, want at least %v", got, 72*time.Hour)
+	}
+}
+
+func TestBulkDecideCategoryRequests(t *testing.T) {
+	pending, decided, missing := uuid.New(), uuid.New(), uuid.New()
+	requests := map[string]*adminModel.CategoryRequest{
+		pending.String(): {RequestID: pending, Status: adminModel.CategoryRequestPending},
+		decided.String(): {RequestID: decided, Status: adminModel.CategoryRequestApproved},
+	}
+
+	repo := &stubRepo{
+		getCategoryRequest: func(_ context.Context, requestID string) (*adminModel.CategoryRequest, error) {
+			if request, ok := requests[requestID]; ok {
+				return request, nil
+			}
+			return nil, gorm.ErrRecordNotFound
+		},
+		decideCategoryRequest: func(context.Context, string, adminModel.CategoryDecision) error { return nil },
+	}
+
+	resp, err := newTestService(repo).BulkDecideCategoryRequests(context.Background(), &pb.BulkDecideCategoryRequestsRequest{
+		RequestIds: []string{pending.String(), decided.String(), pending.String(), "42", missing.String()},
+		Status:     adminModel.CategoryRequestRejected,
+		ReasonCode: adminModel.RejectionDuplicateRequest,
+		DecidedBy:  "admin@zyra.com",
+	})
+	if err != nil {
+		t.Fatalf("BulkDecideCategoryRequests() error = %v", err)
+	}
+
+	want := []struct {
+		id   string
+		code codes.Code
+	}{
+		{pending.String(), codes.OK},
+		{decided.String(), codes.FailedPrecondition},
+		{"42", codes.InvalidArgument},
+		{missing.String(), codes.NotFound},
+	}
+	if len(resp.Results) != len(want) {
+		t.Fatalf("got %d results, want %d (duplicates decided once)", len(resp.Results), len(want))
+	}
+	for i, w := range want {
+		got := resp.Results[i]
+		if got.RequestId != w.id || got.Code != w.code.String() || got.Success != (w.code == codes.OK) {
+			t.Errorf("result %d = %+v, want %s with %v", i, got, w.id, w.code)
+		}
+	}
+	if resp.Succeeded != 1 || resp.Failed != 3 {
+		t.Errorf("succeeded %d, failed %d, want 1, 3", resp.Succeeded, resp.Failed)
+	}
+}
+
+func TestBulkDecideCategoryRequestsValidation(t *testing.T) {
+	tooMany := make([]string, maxBulkCategoryRequests+1)
+	for i := range tooMany {
+		tooMany[i] = uuid.NewString()
+	}
+
+	tests := []struct {
+		name string
+		req  *pb.BulkDecideCategoryRequestsRequest
+	}{
+		{"no requests", &pb.BulkDecideCategoryRequestsRequest{Status: adminModel.CategoryRequestApproved, DecidedBy: "admin@zyra.com"}},
+		{"too many requests", &pb.BulkDecideCategoryRequestsRequest{RequestIds: tooMany, Status: adminModel.CategoryRequestApproved, DecidedBy: "admin@zyra.com"}},
+		{"invalid decision", &pb.BulkDecideCategoryRequestsRequest{RequestIds: tooMany[:2], Status: adminModel.CategoryRequestRejected, DecidedBy: "admin@zyra.com"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := newTestService(&stubRepo{}).BulkDecideCategoryRequests(context.Background(), tt.req)
+			if got := status.Code(err); got != codes.InvalidArgument {
+				t.Errorf("BulkDecideCategoryRequests() = %v, want %v", got, codes.InvalidArgument)
+			}
+		})
+	}
+}
+
+func TestApproveRejectCategoryLegacyReason(t *testing.T) {
+	requestID := uuid.New()
+
+	tests := []struct {
+		name       string
+		req        *pb.ApproveRejectCategoryRequest
+		want       codes.Code
+		wantReason string
+		wantNotes  string
+	}{
+		{
+			name: "legacy rejection without a reason",
+			req: &pb.ApproveRejectCategoryRequest{VendorId: uuid.NewString(), CategoryId: uuid.NewString(),
+				Status: adminModel.CategoryRequestRejected, DecidedBy: "admin@zyra.com"},
+			wantReason: adminModel.RejectionOther,
+			wantNotes:  legacyRejectionNotes,
+		},
+		{
+			name: "legacy rejection keeps its notes",
+			req: &pb.ApproveRejectCategoryRequest{VendorId: uuid.NewString(), CategoryId: uuid.NewString(),
+				Status: adminModel.CategoryRequestRejected, DecidedBy: "admin@zyra.com", Notes: "portfolio is empty"},
+			wantReason: adminModel.RejectionOther,
+			wantNotes:  "portfolio is empty",
+		},
+		{
+			name: "legacy rejection with a reason",
+			req: &pb.ApproveRejectCategoryRequest{VendorId: uuid.NewString(), CategoryId: uuid.NewString(),
+				Status: adminModel.CategoryRequestRejected, DecidedBy: "admin@zyra.com", ReasonCode: adminModel.RejectionDuplicateRequest},
+			wantReason: adminModel.RejectionDuplicateRequest,
+		},
+		{
+			name: "rejection by request ID needs a reason",
+			req: &pb.ApproveRejectCategoryRequest{RequestId: requestID.String(),
+				Status: adminModel.CategoryRequestRejected, DecidedBy: "admin@zyra.com"},
+			want: codes.InvalidArgument,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			request := &adminModel.CategoryRequest{RequestID: requestID, Status: adminModel.CategoryRequestPending}
+			var decided *adminModel.CategoryDecision
+			repo := &stubRepo{
+				getCategoryRequest: func(context.Context, string) (*adminModel.CategoryRequest, error) { return request, nil },
+				findPendingCategoryRequest: func(context.Context, string, string) (*adminModel.CategoryRequest, error) {
+					return request, nil
+				},
+				decideCategoryRequest: func(_ context.Context, _ string, decision adminModel.CategoryDecision) error {
+					decided = &decision
+					return nil
+				},
+			}
+
+			_, err := newTestService(repo).ApproveRejectCategory(context.Background(), tt.req)
+			if got := status.Code(err); got != tt.want {
+				t.Fatalf("ApproveRejectCategory() = %v, want %v (%v)", got, tt.want, err)
+			}
+			if err != nil {
+				return
+			}
+			if decided.ReasonCode != tt.wantReason || decided.Notes != tt.wantNotes {
+				t.Errorf("decided with reason %q and notes %q, want %q and %q", decided.ReasonCode, decided.Notes, tt.wantReason, tt.wantNotes)
+			}
+		})
+	}
+}
+
+func TestApproveRejectCategoryLegacyDecider(t *testing.T) {
+	tests := []struct {
+		name string
+		req  *pb.ApproveRejectCategoryRequest
+		want codes.Code
+		by   string
+	}{
+		{
+			name: "legacy call without a decider",
+			req: &pb.ApproveRejectCategoryRequest{VendorId: uuid.NewString(), CategoryId: uuid.NewString(),
+				Status: adminModel.CategoryRequestRejected, ReasonCode: adminModel.RejectionCategoryMismatch},
+			by: adminModel.UnknownDecider,
+		},
+		{
+			name: "legacy call with a decider",
+			req: &pb.ApproveRejectCategoryRequest{VendorId: uuid.NewString(), CategoryId: uuid.NewString(),
+				Status: adminModel.CategoryRequestRejected, ReasonCode: adminModel.RejectionCategoryMismatch, DecidedBy: "admin@zyra.com"},
+			by: "admin@zyra.com",
+		},
+		{
+			name: "decision by request ID needs a decider",
+			req: &pb.ApproveRejectCategoryRequest{RequestId: uuid.NewString(),
+				Status: adminModel.CategoryRequestRejected, ReasonCode: adminModel.RejectionCategoryMismatch},
+			want: codes.InvalidArgument,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var decidedBy string
+			repo := &stubRepo{
+				findPendingCategoryRequest: func(context.Context, string, string) (*adminModel.CategoryRequest, error) {
+					return &adminModel.CategoryRequest{RequestID: uuid.New(), Status: adminModel.CategoryRequestPending}, nil
+				},
+				decideCategoryRequest: func(_ context.Context, _ string, decision adminModel.CategoryDecision) error {
+					decidedBy = decision.DecidedBy
+					return nil
+				},
+			}
+
+			_, err := newTestService(repo).ApproveRejectCategory(context.Background(), tt.req)
+			if got := status.Code(err); got != tt.want {
+				t.Fatalf("ApproveRejectCategory() = %v, want %v (%v)", got, tt.want, err)
+			}
+			if err == nil && decidedBy != tt.by {
+				t.Errorf("decided by %q, want %q", decidedBy, tt.by)
+			}
+		})
+	}
+}
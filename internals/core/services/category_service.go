@@ -0,0 +1,221 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+
+	pb "github.com/AthulKrishna2501/proto-repo/admin"
+	adminModel "github.com/AthulKrishna2501/zyra-admin-service/internals/core/models"
+	"github.com/AthulKrishna2501/zyra-admin-service/internals/core/repository"
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"gorm.io/gorm"
+)
+
+func (s *AdminService) RenameCategory(ctx context.Context, req *pb.RenameCategoryRequest) (*pb.RenameCategoryResponse, error) {
+	if _, err := uuid.Parse(req.CategoryId); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "Invalid category ID: %v", err)
+	}
+
+	name := adminModel.NormalizeCategoryName(req.CategoryName)
+	if name == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "Category name cannot be empty")
+	}
+
+	if err := s.AdminRepo.RenameCategory(ctx, req.CategoryId, name); err != nil {
+		return nil, categoryError(err, req.CategoryId)
+	}
+
+	return &pb.RenameCategoryResponse{
+		Message: fmt.Sprintf("Category renamed to %s", name),
+	}, nil
+}
+
+func (s *AdminService) DeleteCategory(ctx context.Context, req *pb.DeleteCategoryRequest) (*pb.DeleteCategoryResponse, error) {
+	if _, err := uuid.Parse(req.CategoryId); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "Invalid category ID: %v", err)
+	}
+
+	if err := s.AdminRepo.DeleteCategory(ctx, req.CategoryId); err != nil {
+		return nil, categoryError(err, req.CategoryId)
+	}
+
+	s.log.Info("Admin Service: category deleted", req.CategoryId)
+
+	return &pb.DeleteCategoryResponse{
+		Message: "category deleted successfully",
+	}, nil
+}
+
+func (s *AdminService) RestoreCategory(ctx context.Context, req *pb.RestoreCategoryRequest) (*pb.RestoreCategoryResponse, error) {
+	if _, err := uuid.Parse(req.CategoryId); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "Invalid category ID: %v", err)
+	}
+
+	if err := s.AdminRepo.RestoreCategory(ctx, req.CategoryId); err != nil {
+		return nil, categoryError(err, req.CategoryId)
+	}
+
+	s.log.Info("Admin Service: category restored", req.CategoryId)
+
+	return &pb.RestoreCategoryResponse{
+		Message: "category restored successfully",
+	}, nil
+}
+
+func (s *AdminService) MergeCategories(ctx context.Context, req *pb.MergeCategoriesRequest) (*pb.MergeCategoriesResponse, error) {
+	if _, err := uuid.Parse(req.SourceCategoryId); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "Invalid source category ID: %v", err)
+	}
+	if _, err := uuid.Parse(req.TargetCategoryId); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "Invalid target category ID: %v", err)
+	}
+	if req.SourceCategoryId == req.TargetCategoryId {
+		return nil, status.Errorf(codes.InvalidArgument, "Source and target categories must differ")
+	}
+
+	result, err := s.AdminRepo.MergeCategories(ctx, req.SourceCategoryId, req.TargetCategoryId)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, status.Errorf(codes.NotFound, "Source or target category not found")
+	} else if err != nil {
+		return nil, categoryError(err, req.SourceCategoryId)
+	}
+
+	s.log.Info("Admin Service: categories merged", req.SourceCategoryId, req.TargetCategoryId, result.MovedVendors, result.MovedRequests)
+
+	return &pb.MergeCategoriesResponse{
+		Message:       "categories merged successfully",
+		MovedVendors:  int32(result.MovedVendors),
+		MovedRequests: int32(result.MovedRequests),
+	}, nil
+}
+
+func (s *AdminService) MoveCategory(ctx context.Context, req *pb.MoveCategoryRequest) (*pb.MoveCategoryResponse, error) {
+	if _, err := uuid.Parse(req.CategoryId); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "Invalid category ID: %v", err)
+	}
+
+	var parentID *uuid.UUID
+	if req.NewParentId != "" {
+		parsed, err := uuid.Parse(req.NewParentId)
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "Invalid parent category ID: %v", err)
+		}
+		parentID = &parsed
+	}
+
+	if err := s.AdminRepo.MoveCategory(ctx, req.CategoryId, parentID); err != nil {
+		return nil, categoryError(err, req.CategoryId)
+	}
+
+	s.log.Info("Admin Service: category moved", req.CategoryId, req.NewParentId)
+
+	return &pb.MoveCategoryResponse{
+		Message: "category moved successfully",
+	}, nil
+}
+
+func (s *AdminService) UpdateCategoryDetails(ctx context.Context, req *pb.UpdateCategoryDetailsRequest) (*pb.UpdateCategoryDetailsResponse, error) {
+	if _, err := uuid.Parse(req.CategoryId); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "Invalid category ID: %v", err)
+	}
+
+	update := adminModel.CategoryDetailsUpdate{
+		Description: req.Description,
+		IsActive:    req.IsActive,
+	}
+
+	if req.IconUrl != nil {
+		if *req.IconUrl != "" && !isHTTPURL(*req.IconUrl) {
+			return nil, status.Errorf(codes.InvalidArgument, "Icon URL must be an absolute http(s) URL")
+		}
+		update.IconURL = req.IconUrl
+	}
+
+	if req.DisplayOrder != nil {
+		order := int(*req.DisplayOrder)
+		update.DisplayOrder = &order
+	}
+
+	if req.Slug != nil {
+		if *req.Slug != "" && !slugPattern.MatchString(*req.Slug) {
+			return nil, status.Errorf(codes.InvalidArgument, "Slug may only contain lowercase letters, digits and single hyphens")
+		}
+		update.Slug = req.Slug
+	}
+
+	if req.CommissionRate != nil {
+		if *req.CommissionRate < 0 || *req.CommissionRate > 100 {
+			return nil, status.Errorf(codes.InvalidArgument, "Commission rate must be between 0 and 100")
+		}
+		update.CommissionRate = req.CommissionRate
+	}
+
+	if err := s.AdminRepo.UpdateCategoryDetails(ctx, req.CategoryId, update); err != nil {
+		return nil, categoryError(err, req.CategoryId)
+	}
+
+	s.log.Info("Admin Service: category details updated", req.CategoryId)
+
+	return &pb.UpdateCategoryDetailsResponse{
+		Message: "category details updated successfully",
+	}, nil
+}
+
+// uniqueCategorySlug derives a slug from the category name and appends a
+// numeric suffix until it no longer collides with an existing category.
+func (s *AdminService) uniqueCategorySlug(ctx context.Context, name string) (string, error) {
+	base := slugify(name)
+	if base == "" {
+		return "", nil
+	}
+
+	slug := base
+	for i := 2; ; i++ {
+		taken, err := s.AdminRepo.SlugExists(ctx, slug)
+		if err != nil {
+			return "", err
+		}
+		if !taken {
+			return slug, nil
+		}
+		slug = fmt.Sprintf("%s-%d", base, i)
+	}
+}
+
+var (
+	slugPattern    = regexp.MustCompile(`^[a-z0-9]+(-[a-z0-9]+)*$`)
+	nonSlugPattern = regexp.MustCompile(`[^a-z0-9]+`)
+)
+
+func slugify(name string) string {
+	return strings.Trim(nonSlugPattern.ReplaceAllString(strings.ToLower(name), "-"), "-")
+}
+
+func isHTTPURL(raw string) bool {
+	u, err := url.Parse(raw)
+	return err == nil && (u.Scheme == "http" || u.Scheme == "https") && u.Host != ""
+}
+
+func categoryError(err error, categoryID string) error {
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		return status.Errorf(codes.NotFound, "Category %s not found", categoryID)
+	case errors.Is(err, repository.ErrCategoryExists):
+		return status.Errorf(codes.AlreadyExists, "A category with this name already exists under the same parent")
+	case errors.Is(err, repository.ErrSlugExists):
+		return status.Errorf(codes.AlreadyExists, "%v", err)
+	case errors.Is(err, repository.ErrParentNotFound),
+		errors.Is(err, repository.ErrCategoryCycle),
+		errors.Is(err, repository.ErrCategoryHasChildren),
+		errors.Is(err, repository.ErrSubcategoryConflict):
+		return status.Errorf(codes.FailedPrecondition, "%v", err)
+	default:
+		return status.Errorf(codes.Internal, "Category operation failed: %v", err)
+	}
+}
@@ -0,0 +1,189 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	pb "github.com/AthulKrishna2501/proto-repo/admin"
+	"github.com/AthulKrishna2501/zyra-admin-service/internals/core/repository"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"gorm.io/gorm"
+)
+
+func TestCategoryError(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want codes.Code
+	}{
+		{"not found", gorm.ErrRecordNotFound, codes.NotFound},
+		{"name taken", repository.ErrCategoryExists, codes.AlreadyExists},
+		{"slug taken", repository.ErrSlugExists, codes.AlreadyExists},
+		{"missing parent", repository.ErrParentNotFound, codes.FailedPrecondition},
+		{"cycle", repository.ErrCategoryCycle, codes.FailedPrecondition},
+		{"has children", repository.ErrCategoryHasChildren, codes.FailedPrecondition},
+		{"merge conflict", fmt.Errorf("%w: Catering", repository.ErrSubcategoryConflict), codes.FailedPrecondition},
+		{"unknown", errors.New("connection reset"), codes.Internal},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := status.Code(categoryError(tt.err, "category-1")); got != tt.want {
+				t.Errorf("categoryError(%v) = %v, want %v", tt.err, got, tt.want)
+			}
+		})
+	}
+}
+
+// TestCategoryLifecycleValidation checks that malformed requests are refused
+// before the repository is reached; the stub has no methods set, so a call
+// through to it would panic.
+func TestCategoryLifecycleValidation(t *testing.T) {
+	const id = "5b0c7a62-2f3e-4b8e-9a55-0f1f0c7d3a11"
+	s := newTestService(&stubRepo{})
+	ctx := context.Background()
+
+	tests := []struct {
+		name string
+		call func() error
+	}{
+		{"rename with bad ID", func() error {
+			_, err := s.RenameCategory(ctx, &pb.RenameCategoryRequest{CategoryId: "nope", CategoryName: "Catering"})
+			return err
+		}},
+		{"rename to blank name", func() error {
+			_, err := s.RenameCategory(ctx, &pb.RenameCategoryRequest{CategoryId: id, CategoryName: "   "})
+			return err
+		}},
+		{"delete with bad ID", func() error {
+			_, err := s.DeleteCategory(ctx, &pb.DeleteCategoryRequest{CategoryId: ""})
+			return err
+		}},
+		{"restore with bad ID", func() error {
+			_, err := s.RestoreCategory(ctx, &pb.RestoreCategoryRequest{CategoryId: "nope"})
+			return err
+		}},
+		{"merge with bad source", func() error {
+			_, err := s.MergeCategories(ctx, &pb.MergeCategoriesRequest{SourceCategoryId: "nope", TargetCategoryId: id})
+			return err
+		}},
+		{"merge with bad target", func() error {
+			_, err := s.MergeCategories(ctx, &pb.MergeCategoriesRequest{SourceCategoryId: id, TargetCategoryId: "nope"})
+			return err
+		}},
+		{"merge into itself", func() error {
+			_, err := s.MergeCategories(ctx, &pb.MergeCategoriesRequest{SourceCategoryId: id, TargetCategoryId: id})
+			return err
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := status.Code(tt.call()); got != codes.InvalidArgument {
+				t.Errorf("got %v, want %v", got, codes.InvalidArgument)
+			}
+		})
+	}
+}
+
+func TestSlugify(t *testing.T) {
+	tests := []struct {
+		name string
+		want string
+	}{
+		{"Wedding Photography", "wedding-photography"},
+		{"  DJ & Music!  ", "dj-music"},
+		{"Café Catering", "caf-catering"},
+		{"2024 Events", "2024-events"},
+		{"---", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := slugify(tt.name); got != tt.want {
+				t.Errorf("slugify(%q) = %q, want %q", tt.name, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIsHTTPURL(t *testing.T) {
+	tests := []struct {
+		raw  string
+		want bool
+	}{
+		{"https://cdn.zyra.com/icons/photo.png", true},
+		{"http://cdn.zyra.com/photo.png", true},
+		{"ftp://cdn.zyra.com/photo.png", false},
+		{"/icons/photo.png", false},
+		{"https://", false},
+		{"javascript:alert(1)", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.raw, func(t *testing.T) {
+			if got := isHTTPURL(tt.raw); got != tt.want {
+				t.Errorf("isHTTPURL(%q) = %v, want %v", tt.raw, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestUniqueCategorySlug(t *testing.T) {
+	tests := []struct {
+		name     string
+		category string
+		taken    map[string]bool
+		want     string
+	}{
+		{"free", "Wedding Photography", nil, "wedding-photography"},
+		{"taken once", "Catering", map[string]bool{"catering": true}, "catering-2"},
+		{"taken twice", "Catering", map[string]bool{"catering": true, "catering-2": true}, "catering-3"},
+		{"nothing to slug", "!!!", nil, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newTestService(&stubRepo{
+				slugExists: func(ctx context.Context, slug string) (bool, error) {
+					return tt.taken[slug], nil
+				},
+			})
+
+			got, err := s.uniqueCategorySlug(context.Background(), tt.category)
+			if err != nil {
+				t.Fatalf("uniqueCategorySlug() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("uniqueCategorySlug(%q) = %q, want %q", tt.category, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestUpdateCategoryDetailsValidation(t *testing.T) {
+	const id = "5b0c7a62-2f3e-4b8e-9a55-0f1f0c7d3a11"
+
+	tests := []struct {
+		name string
+		req  *pb.UpdateCategoryDetailsRequest
+	}{
+		{"bad ID", &pb.UpdateCategoryDetailsRequest{CategoryId: "nope"}},
+		{"relative icon URL", &pb.UpdateCategoryDetailsRequest{CategoryId: id, IconUrl: ptr("/icons/photo.png")}},
+		{"bad slug", &pb.UpdateCategoryDetailsRequest{CategoryId: id, Slug: ptr("Wedding--Photo")}},
+		{"negative commission", &pb.UpdateCategoryDetailsRequest{CategoryId: id, CommissionRate: ptr(-1.0)}},
+		{"commission over 100", &pb.UpdateCategoryDetailsRequest{CategoryId: id, CommissionRate: ptr(100.5)}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := newTestService(&stubRepo{}).UpdateCategoryDetails(context.Background(), tt.req)
+			if got := status.Code(err); got != codes.InvalidArgument {
+				t.Errorf("UpdateCategoryDetails() = %v, want %v", got, codes.InvalidArgument)
+			}
+		})
+	}
+}
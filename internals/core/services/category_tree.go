@@ -0,0 +1,74 @@
+package services
+
+import (
+	"strings"
+
+	pb "github.com/AthulKrishna2501/proto-repo/admin"
+	adminModel "github.com/AthulKrishna2501/zyra-admin-service/internals/core/models"
+)
+
+const categoryPathSeparator = " > "
+
+// buildCategoryList converts categories into their protobuf form with the
+// parent ID, depth and full path ("Photography > Wedding Photography") set.
+// With tree set only the root categories are returned and every category
+// carries its subcategories in Children; otherwise the flat list is returned
+// in depth-first order. A category whose parent is not in categories, such
+// as the child of an inactive category when listing active ones, is left
+// out together with its subtree.
+func buildCategoryList(categories []adminModel.Category, tree bool) []*pb.Category {
+	nodes := make(map[string]*pb.Category, len(categories))
+	for _, cat := range categories {
+		node := &pb.Category{
+			CategoryId:     cat.CategoryID.String(),
+			CategoryName:   cat.CategoryName,
+			Description:    cat.Description,
+			IconUrl:        cat.IconURL,
+			DisplayOrder:   int32(cat.DisplayOrder),
+			IsActive:       cat.IsActive,
+			Slug:           cat.Slug,
+			CommissionRate: cat.CommissionRate,
+		}
+		if cat.ParentID != nil {
+			node.ParentId = cat.ParentID.String()
+		}
+		nodes[node.CategoryId] = node
+	}
+
+	var roots []*pb.Category
+	children := make(map[string][]*pb.Category)
+	for _, cat := range categories {
+		node := nodes[cat.CategoryID.String()]
+		if node.ParentId == "" {
+			roots = append(roots, node)
+			continue
+		}
+		children[node.ParentId] = append(children[node.ParentId], node)
+	}
+
+	var flat []*pb.Category
+	var walk func(node *pb.Category, depth int32, path []string)
+	walk = func(node *pb.Category, depth int32, path []string) {
+		path = append(path, node.CategoryName)
+		node.Depth = depth
+		node.Path = strings.Join(path, categoryPathSeparator)
+		flat = append(flat, node)
+
+		for _, child := range children[node.CategoryId] {
+			if tree {
+				node.Children = append(node.Children, child)
+			}
+			walk(child, depth+1, path)
+		}
+	}
+
+	for _, root := range roots {
+		walk(root, 0, nil)
+	}
+
+	if tree {
+		return roots
+	}
+
+	return flat
+}
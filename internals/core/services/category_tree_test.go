@@ -0,0 +1,106 @@
+package services
+
+import (
+	"reflect"
+	"testing"
+
+	pb "github.com/AthulKrishna2501/proto-repo/admin"
+	adminModel "github.com/AthulKrishna2501/zyra-admin-service/internals/core/models"
+	"github.com/google/uuid"
+)
+
+func TestBuildCategoryList(t *testing.T) {
+	photo, wedding, drone, catering := uuid.New(), uuid.New(), uuid.New(), uuid.New()
+	venues, rooftops, terraces := uuid.New(), uuid.New(), uuid.New()
+
+	all := []adminModel.Category{
+		{CategoryID: photo, CategoryName: "Photography"},
+		{CategoryID: wedding, CategoryName: "Wedding Photography", ParentID: &photo},
+		{CategoryID: drone, CategoryName: "Drone", ParentID: &wedding},
+		{CategoryID: catering, CategoryName: "Catering"},
+	}
+	// Venues is inactive, so listing active categories leaves it out but
+	// returns its active subcategories.
+	activeOnly := append(all[:len(all):len(all)],
+		adminModel.Category{CategoryID: rooftops, CategoryName: "Rooftops", ParentID: &venues},
+		adminModel.Category{CategoryID: terraces, CategoryName: "Terraces", ParentID: &rooftops},
+	)
+
+	type entry struct {
+		ID    uuid.UUID
+		Depth int32
+		Path  string
+	}
+	flatten := func(nodes []*pb.Category) []entry {
+		var out []entry
+		for _, node := range nodes {
+			out = append(out, entry{uuid.MustParse(node.CategoryId), node.Depth, node.Path})
+		}
+		return out
+	}
+
+	tests := []struct {
+		name       string
+		categories []adminModel.Category
+		tree       bool
+		want       []entry
+		kids       map[uuid.UUID]int
+	}{
+		{
+			name:       "flat depth-first",
+			categories: all,
+			want: []entry{
+				{photo, 0, "Photography"},
+				{wedding, 1, "Photography > Wedding Photography"},
+				{drone, 2, "Photography > Wedding Photography > Drone"},
+				{catering, 0, "Catering"},
+			},
+		},
+		{
+			name:       "tree roots only",
+			categories: all,
+			tree:       true,
+			want: []entry{
+				{photo, 0, "Photography"},
+				{catering, 0, "Catering"},
+			},
+			kids: map[uuid.UUID]int{photo: 1, catering: 0},
+		},
+		{
+			name:       "inactive parent drops its subtree",
+			categories: activeOnly,
+			want: []entry{
+				{photo, 0, "Photography"},
+				{wedding, 1, "Photography > Wedding Photography"},
+				{drone, 2, "Photography > Wedding Photography > Drone"},
+				{catering, 0, "Catering"},
+			},
+		},
+		{
+			name:       "inactive parent in a tree",
+			categories: activeOnly,
+			tree:       true,
+			want: []entry{
+				{photo, 0, "Photography"},
+				{catering, 0, "Catering"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := buildCategoryList(tt.categories, tt.tree)
+			if !reflect.DeepEqual(flatten(got), tt.want) {
+				t.Errorf("buildCategoryList() = %+v, want %+v", flatten(got), tt.want)
+			}
+			for _, node := range got {
+				if n, ok := tt.kids[uuid.MustParse(node.CategoryId)]; ok && len(node.Children) != n {
+					t.Errorf("%s has %d children, want %d", node.CategoryName, len(node.Children), n)
+				}
+				if !tt.tree && len(node.Children) != 0 {
+					t.Errorf("%s carries children in the flat list", node.CategoryName)
+				}
+			}
+		})
+	}
+}
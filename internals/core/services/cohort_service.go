@@ -0,0 +1,146 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"fmt"
+	"strconv"
+	"time"
+
+	pb "github.com/AthulKrishna2501/proto-repo/admin"
+	adminModel "github.com/AthulKrishna2501/zyra-admin-service/internals/core/models"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const (
+	defaultCohortMonths = 12
+	maxCohortMonths     = 36
+)
+
+func (s *AdminService) GetClientCohorts(ctx context.Context, req *pb.GetClientCohortsRequest) (*pb.GetClientCohortsResponse, error) {
+	cohorts, period, months, err := s.clientCohorts(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.GetClientCohortsResponse{
+		From:    timestamppb.New(period.From),
+		To:      timestamppb.New(period.To),
+		Months:  int32(months),
+		Cohorts: cohorts,
+	}, nil
+}
+
+// ExportClientCohorts returns the retention matrix as CSV, one row per
+// cohort with the share of the cohort that booked in each month after
+// signing up. Months that have not started yet are left empty.
+func (s *AdminService) ExportClientCohorts(ctx context.Context, req *pb.GetClientCohortsRequest) (*pb.ExportClientCohortsResponse, error) {
+	cohorts, _, months, err := s.clientCohorts(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := encodeCohortCSV(cohorts, months)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Failed to encode cohorts: %v", err)
+	}
+
+	return &pb.ExportClientCohortsResponse{
+		FileName:    fmt.Sprintf("client-cohorts-%s.csv", time.Now().UTC().Format("20060102")),
+		ContentType: "text/csv",
+		Data:        data,
+	}, nil
+}
+
+// encodeCohortCSV writes one row per cohort with a column for each month
+// offset up to months. Offsets outside that range are dropped.
+func encodeCohortCSV(cohorts []*pb.ClientCohort, months int) ([]byte, error) {
+	header := []string{"cohort", "clients"}
+	for m := 0; m <= months; m++ {
+		header = append(header, fmt.Sprintf("month_%d", m))
+	}
+
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+	if err := w.Write(header); err != nil {
+		return nil, err
+	}
+	for _, c := range cohorts {
+		row := make([]string, len(header))
+		row[0] = c.CohortMonth.AsTime().UTC().Format("2006-01")
+		row[1] = strconv.Itoa(int(c.Size))
+		for _, r := range c.Retention {
+			if r.MonthOffset < 0 || int(r.MonthOffset) > months {
+				continue
+			}
+			row[2+int(r.MonthOffset)] = strconv.FormatFloat(r.Rate, 'f', 4, 64)
+		}
+		if err := w.Write(row); err != nil {
+			return nil, err
+		}
+	}
+	w.Flush()
+	if err := w.Error(); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+// clientCohorts builds the retention matrix for the request. Cohorts
+// default to the sign-up months of the last year, including the current
+// one. Months are calendar months in UTC, as in the repository.
+func (s *AdminService) clientCohorts(ctx context.Context, req *pb.GetClientCohortsRequest) ([]*pb.ClientCohort, adminModel.Period, int, error) {
+	now := time.Now().UTC()
+
+	months := int(req.Months)
+	if months <= 0 {
+		months = defaultCohortMonths
+	}
+	if months > maxCohortMonths {
+		return nil, adminModel.Period{}, 0, status.Errorf(codes.InvalidArgument, "Months cannot exceed %d", maxCohortMonths)
+	}
+
+	period := adminModel.Period{To: now}
+	if req.To != nil {
+		period.To = req.To.AsTime()
+	}
+
+	thisMonth := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+	period.From = thisMonth.AddDate(0, -(defaultCohortMonths - 1), 0)
+	if req.From != nil {
+		period.From = req.From.AsTime()
+	}
+
+	if !period.From.Before(period.To) {
+		return nil, period, 0, status.Errorf(codes.InvalidArgument, "From must be before To")
+	}
+
+	cells, err := s.AdminRepo.GetClientCohorts(ctx, period, months, now)
+	if err != nil {
+		return nil, period, 0, status.Errorf(codes.Internal, "Failed to fetch client cohorts: %v", err)
+	}
+
+	var cohorts []*pb.ClientCohort
+	var current *pb.ClientCohort
+	for _, cell := range cells {
+		if current == nil || !current.CohortMonth.AsTime().Equal(cell.Cohort) {
+			current = &pb.ClientCohort{
+				CohortMonth: timestamppb.New(cell.Cohort),
+				Size:        int32(cell.Size),
+			}
+			cohorts = append(cohorts, current)
+		}
+
+		current.Retention = append(current.Retention, &pb.CohortRetention{
+			MonthOffset:   int32(cell.MonthOffset),
+			ActiveClients: int32(cell.ActiveClients),
+			Rate:          rate(cell.ActiveClients, cell.Size),
+		})
+	}
+
+	return cohorts, period, months, nil
+}
@@ -0,0 +1,89 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	pb "github.com/AthulKrishna2501/proto-repo/admin"
+	adminModel "github.com/AthulKrishna2501/zyra-admin-service/internals/core/models"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func TestEncodeCohortCSV(t *testing.T) {
+	march := timestamppb.New(time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC))
+
+	tests := []struct {
+		name    string
+		cohorts []*pb.ClientCohort
+		months  int
+		want    string
+	}{
+		{
+			name:   "no cohorts",
+			months: 1,
+			want:   "cohort,clients,month_0,month_1\n",
+		},
+		{
+			name: "partial row",
+			cohorts: []*pb.ClientCohort{{
+				CohortMonth: march,
+				Size:        4,
+				Retention: []*pb.CohortRetention{
+					{MonthOffset: 0, Rate: 1},
+					{MonthOffset: 1, Rate: 0.25},
+				},
+			}},
+			months: 2,
+			want:   "cohort,clients,month_0,month_1,month_2\n2025-03,4,1.0000,0.2500,\n",
+		},
+		{
+			name: "offsets out of range are dropped",
+			cohorts: []*pb.ClientCohort{{
+				CohortMonth: march,
+				Size:        2,
+				Retention: []*pb.CohortRetention{
+					{MonthOffset: -1, Rate: 0.5},
+					{MonthOffset: 0, Rate: 1},
+					{MonthOffset: 3, Rate: 0.5},
+				},
+			}},
+			months: 1,
+			want:   "cohort,clients,month_0,month_1\n2025-03,2,1.0000,\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := encodeCohortCSV(tt.cohorts, tt.months)
+			if err != nil {
+				t.Fatalf("encodeCohortCSV() error = %v", err)
+			}
+			if string(got) != tt.want {
+				t.Errorf("encodeCohortCSV() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestClientCohortsDefaultPeriodIsUTC(t *testing.T) {
+	var got adminModel.Period
+	repo := &stubRepo{
+		getClientCohorts: func(_ context.Context, period adminModel.Period, _ int, _ time.Time) ([]adminModel.ClientCohortCell, error) {
+			got = period
+			return nil, nil
+		},
+	}
+
+	if _, _, _, err := newTestService(repo).clientCohorts(context.Background(), &pb.GetClientCohortsRequest{}); err != nil {
+		t.Fatalf("clientCohorts() error = %v", err)
+	}
+
+	from := got.From
+	if from.Location() != time.UTC || from.Day() != 1 || from.Hour() != 0 || from.Minute() != 0 {
+		t.Errorf("default from = %v, want the start of a month in UTC", from)
+	}
+	if months := (got.To.Year()-from.Year())*12 + int(got.To.Month()-from.Month()); months != defaultCohortMonths-1 {
+		t.Errorf("default period spans %d months, want %d", months, defaultCohortMonths-1)
+	}
+}
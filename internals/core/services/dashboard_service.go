@@ -0,0 +1,171 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"time"
+
+	pb "github.com/AthulKrishna2501/proto-repo/admin"
+	adminModel "github.com/AthulKrishna2501/zyra-admin-service/internals/core/models"
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const (
+	dashboardCacheKey = "admin_dashboard_stats"
+	dashboardCacheTTL = 30 * time.Second
+
+	dashboardCompactInterval   = time.Minute
+	dashboardReconcileInterval = 24 * time.Hour
+)
+
+// cachedDashboardStats serves the all-time dashboard from Redis when it can.
+// The counters behind it are cheap to read already; the cache spares the
+// database when many admins poll the dashboard at once. A Redis failure
+// falls through to the database.
+func (s *AdminService) cachedDashboardStats(ctx context.Context) (*adminModel.DashboardStats, error) {
+	if s.redisClient != nil {
+		if cached, err := s.redisClient.Get(ctx, dashboardCacheKey).Bytes(); err == nil {
+			var stats adminModel.DashboardStats
+			if err := json.Unmarshal(cached, &stats); err == nil {
+				return &stats, nil
+			}
+		}
+	}
+
+	stats, err := s.AdminRepo.GetAdminDashboard(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if s.redisClient != nil {
+		if encoded, err := json.Marshal(stats); err == nil {
+			if err := s.redisClient.Set(ctx, dashboardCacheKey, encoded, dashboardCacheTTL).Err(); err != nil {
+				s.log.Warn("Admin Service: failed to cache dashboard stats", err)
+			}
+		}
+	}
+
+	return stats, nil
+}
+
+// StartDashboardReconciler recounts the tables once at startup, then folds
+// the counter deltas into the counters every minute and recounts once a
+// day, logging any drift, as a safety net for writes that bypassed the
+// triggers. The startup pass also catches drift from while the service was
+// down.
+func (s *AdminService) StartDashboardReconciler(ctx context.Context) {
+	compact := time.NewTicker(dashboardCompactInterval)
+	defer compact.Stop()
+	reconcile := time.NewTicker(dashboardReconcileInterval)
+	defer reconcile.Stop()
+
+	s.reconcileDashboard(ctx)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-compact.C:
+			if err := s.AdminRepo.CompactDashboardCounters(ctx); err != nil {
+				s.log.Error("Admin Service: failed to compact dashboard counters", err)
+			}
+		case <-reconcile.C:
+			s.reconcileDashboard(ctx)
+		}
+	}
+}
+
+func (s *AdminService) reconcileDashboard(ctx context.Context) {
+	before, after, err := s.AdminRepo.ReconcileDashboardCounters(ctx)
+	if err != nil {
+		s.log.Error("Admin Service: failed to reconcile dashboard counters", err)
+		return
+	}
+	if *before != *after {
+		s.log.Warn("Admin Service: dashboard counters drifted and were corrected", before, after)
+	}
+}
+
+func hasDashboardFilters(req *pb.AdminDashBoardRequest) bool {
+	return req.From != nil || req.To != nil || req.CategoryId != "" || req.Service != "" || len(req.BookingStatuses) > 0
+}
+
+// filteredDashboard answers a dashboard request with filters. When the
+// request has a date bound, the range is resolved like the analytics
+// endpoints do and the stats are compared against the preceding period of
+// the same length with the same segment filters. Segment filters alone
+// cover all time and have nothing to compare against.
+func (s *AdminService) filteredDashboard(ctx context.Context, req *pb.AdminDashBoardRequest) (*pb.AdminDashBoardResponse, error) {
+	filter := adminModel.DashboardFilter{
+		Service: strings.TrimSpace(req.Service),
+	}
+
+	dated := req.From != nil || req.To != nil
+	if dated {
+		period, err := analyticsPeriod(req.From, req.To)
+		if err != nil {
+			return nil, err
+		}
+		filter.From, filter.To = period.From, period.To
+	}
+
+	if req.CategoryId != "" {
+		if _, err := uuid.Parse(req.CategoryId); err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "Invalid category ID: %v", err)
+		}
+
+		category, err := s.AdminRepo.GetCategory(ctx, req.CategoryId)
+		if err != nil {
+			return nil, categoryError(err, req.CategoryId)
+		}
+		filter.CategoryName = category.CategoryName
+	}
+
+	for _, st := range req.BookingStatuses {
+		if st = strings.TrimSpace(st); st != "" {
+			filter.Statuses = append(filter.Statuses, strings.ToLower(st))
+		}
+	}
+
+	stats, err := s.AdminRepo.GetFilteredDashboard(ctx, filter)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Failed to fetch dashboard: %v", err)
+	}
+
+	resp := &pb.AdminDashBoardResponse{
+		TotalVendors:  stats.TotalVendors,
+		TotalClients:  stats.TotalClients,
+		TotalBookings: stats.TotalBookings,
+		TotalRevenue:  stats.TotalRevenue,
+	}
+
+	if !dated {
+		return resp, nil
+	}
+
+	period := adminModel.Period{From: filter.From, To: filter.To}.Previous()
+	previousFilter := filter
+	previousFilter.From, previousFilter.To = period.From, period.To
+
+	previous, err := s.AdminRepo.GetFilteredDashboard(ctx, previousFilter)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Failed to fetch previous period: %v", err)
+	}
+
+	resp.From = timestamppb.New(filter.From)
+	resp.To = timestamppb.New(filter.To)
+	resp.PreviousFrom = timestamppb.New(period.From)
+	resp.PreviousTo = timestamppb.New(period.To)
+	resp.Deltas = []*pb.MetricComparison{
+		compareMetric("total_vendors", float64(stats.TotalVendors), float64(previous.TotalVendors)),
+		compareMetric("total_clients", float64(stats.TotalClients), float64(previous.TotalClients)),
+		compareMetric("total_bookings", float64(stats.TotalBookings), float64(previous.TotalBookings)),
+		compareMetric("total_revenue", float64(stats.TotalRevenue), float64(previous.TotalRevenue)),
+	}
+
+	return resp, nil
+}
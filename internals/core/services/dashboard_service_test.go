@@ -0,0 +1,89 @@
+package services
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	pb "github.com/AthulKrishna2501/proto-repo/admin"
+	adminModel "github.com/AthulKrishna2501/zyra-admin-service/internals/core/models"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func TestFilteredDashboardDeltas(t *testing.T) {
+	to := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+	from := time.Date(2025, 5, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name       string
+		req        *pb.AdminDashBoardRequest
+		wantDeltas bool
+		wantFrom   time.Time
+	}{
+		{
+			name:       "from and to",
+			req:        &pb.AdminDashBoardRequest{From: timestamppb.New(from), To: timestamppb.New(to)},
+			wantDeltas: true,
+			wantFrom:   from,
+		},
+		{
+			name:       "to only",
+			req:        &pb.AdminDashBoardRequest{To: timestamppb.New(to)},
+			wantDeltas: true,
+			wantFrom:   to.Add(-defaultAnalyticsPeriod),
+		},
+		{
+			name: "segment only",
+			req:  &pb.AdminDashBoardRequest{Service: " Catering "},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var filters []adminModel.DashboardFilter
+			repo := &stubRepo{
+				getFilteredDashboard: func(_ context.Context, filter adminModel.DashboardFilter) (*adminModel.DashboardStats, error) {
+					filters = append(filters, filter)
+					return &adminModel.DashboardStats{TotalBookings: int32(len(filters))}, nil
+				},
+			}
+
+			resp, err := newTestService(repo).filteredDashboard(context.Background(), tt.req)
+			if err != nil {
+				t.Fatalf("filteredDashboard() error = %v", err)
+			}
+
+			if got := len(resp.Deltas) > 0; got != tt.wantDeltas {
+				t.Fatalf("deltas returned = %v, want %v", got, tt.wantDeltas)
+			}
+			if !filters[0].From.Equal(tt.wantFrom) {
+				t.Errorf("from = %v, want %v", filters[0].From, tt.wantFrom)
+			}
+			if tt.wantDeltas && !filters[1].To.Equal(filters[0].From) {
+				t.Errorf("previous period ends %v, want %v", filters[1].To, filters[0].From)
+			}
+			if want := strings.TrimSpace(tt.req.Service); filters[0].Service != want {
+				t.Errorf("service = %q, want %q", filters[0].Service, want)
+			}
+		})
+	}
+}
+
+func TestDashboardReconcilerRunsAtStartup(t *testing.T) {
+	var reconciled int
+	repo := &stubRepo{
+		reconcileDashboardCounters: func(context.Context) (*adminModel.DashboardStats, *adminModel.DashboardStats, error) {
+			reconciled++
+			return &adminModel.DashboardStats{TotalRevenue: 100}, &adminModel.DashboardStats{TotalRevenue: 150}, nil
+		},
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	newTestService(repo).StartDashboardReconciler(ctx)
+
+	if reconciled != 1 {
+		t.Errorf("reconciled %d times before shutdown, want 1", reconciled)
+	}
+}
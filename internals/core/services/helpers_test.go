@@ -0,0 +1,149 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	adminModel "github.com/AthulKrishna2501/zyra-admin-service/internals/core/models"
+	"github.com/AthulKrishna2501/zyra-admin-service/internals/core/repository"
+)
+
+// nopLogger discards everything logged by the service under test.
+type nopLogger struct{}
+
+func (nopLogger) Info(string, ...interface{})  {}
+func (nopLogger) Error(string, ...interface{}) {}
+func (nopLogger) Debug(string, ...interface{}) {}
+func (nopLogger) Warn(string, ...interface{})  {}
+
+// stubRepo implements the repository methods the tests need through
+// function fields. Calling any other method panics on the nil embedded
+// interface, which flags an unexpected call.
+type stubRepo struct {
+	repository.AdminRepository
+
+	setUsersBlocked              func(ctx context.Context, userIDs []string, blocked bool) ([]adminModel.BlockUpdate, error)
+	getUserRole                  func(ctx context.Context, userID string) (string, error)
+	hideVendorCategories         func(ctx context.Context, vendorID, reason string) (int64, error)
+	cancelUpcomingVendorBookings func(ctx context.Context, vendorID, adminEmail string) ([]adminModel.Booking, error)
+	holdPayouts                  func(ctx context.Context, userID, reason string) (int64, error)
+	changeUserRole               func(ctx context.Context, change *adminModel.RoleChange, removeVendorData bool) error
+	getUserStatus                func(ctx context.Context, userID string) (string, error)
+	getLatestVendorVerification  func(ctx context.Context, vendorID string) (*adminModel.VendorVerification, error)
+	slugExists                   func(ctx context.Context, slug string) (bool, error)
+	getCategoryRequest           func(ctx context.Context, requestID string) (*adminModel.CategoryRequest, error)
+	findPendingCategoryRequest   func(ctx context.Context, vendorID, categoryID string) (*adminModel.CategoryRequest, error)
+	decideCategoryRequest        func(ctx context.Context, requestID string, decision adminModel.CategoryDecision) error
+	autoApproveCategoryRequest   func(ctx context.Context, decision adminModel.CategoryDecision, evaluation *adminModel.AutoApprovalEvaluation) error
+	getCategoryUsage             func(ctx context.Context, period adminModel.Period) ([]adminModel.CategoryUsage, error)
+	getCategory                  func(ctx context.Context, categoryID string) (*adminModel.Category, error)
+	revokeVendorCategory         func(ctx context.Context, revocation *adminModel.CategoryRevocation, categoryName, adminEmail string) ([]adminModel.Booking, error)
+	getVendorStanding            func(ctx context.Context, vendorID string) (*adminModel.VendorStanding, error)
+	recordAutoApprovalEvaluation func(ctx context.Context, evaluation *adminModel.AutoApprovalEvaluation) error
+	reconcileDashboardCounters   func(ctx context.Context) (before, after *adminModel.DashboardStats, err error)
+	getTimeSeries                func(ctx context.Context, period adminModel.Period, granularity string) ([]adminModel.TimeSeriesPoint, error)
+	getFilteredDashboard         func(ctx context.Context, filter adminModel.DashboardFilter) (*adminModel.DashboardStats, error)
+	getClientCohorts             func(ctx context.Context, period adminModel.Period, months int, now time.Time) ([]adminModel.ClientCohortCell, error)
+	findAmountSpikes             func(ctx context.Context, period adminModel.Period, settings *adminModel.AnomalySettings) ([]adminModel.AnomalyCandidate, error)
+	findMovementBursts           func(ctx context.Context, period adminModel.Period, sources []string, window time.Duration, threshold int) ([]adminModel.AnomalyCandidate, error)
+}
+
+func (r *stubRepo) SetUsersBlocked(ctx context.Context, userIDs []string, blocked bool) ([]adminModel.BlockUpdate, error) {
+	return r.setUsersBlocked(ctx, userIDs, blocked)
+}
+
+func (r *stubRepo) GetUserRole(ctx context.Context, userID string) (string, error) {
+	return r.getUserRole(ctx, userID)
+}
+
+func (r *stubRepo) HideVendorCategories(ctx context.Context, vendorID, reason string) (int64, error) {
+	return r.hideVendorCategories(ctx, vendorID, reason)
+}
+
+func (r *stubRepo) CancelUpcomingVendorBookings(ctx context.Context, vendorID, adminEmail string) ([]adminModel.Booking, error) {
+	return r.cancelUpcomingVendorBookings(ctx, vendorID, adminEmail)
+}
+
+func (r *stubRepo) HoldPayouts(ctx context.Context, userID, reason string) (int64, error) {
+	return r.holdPayouts(ctx, userID, reason)
+}
+
+func (r *stubRepo) ChangeUserRole(ctx context.Context, change *adminModel.RoleChange, removeVendorData bool) error {
+	return r.changeUserRole(ctx, change, removeVendorData)
+}
+
+func (r *stubRepo) GetUserStatus(ctx context.Context, userID string) (string, error) {
+	return r.getUserStatus(ctx, userID)
+}
+
+func (r *stubRepo) GetLatestVendorVerification(ctx context.Context, vendorID string) (*adminModel.VendorVerification, error) {
+	return r.getLatestVendorVerification(ctx, vendorID)
+}
+
+func (r *stubRepo) SlugExists(ctx context.Context, slug string) (bool, error) {
+	return r.slugExists(ctx, slug)
+}
+
+func (r *stubRepo) GetCategoryRequest(ctx context.Context, requestID string) (*adminModel.CategoryRequest, error) {
+	return r.getCategoryRequest(ctx, requestID)
+}
+
+func (r *stubRepo) FindPendingCategoryRequest(ctx context.Context, vendorID, categoryID string) (*adminModel.CategoryRequest, error) {
+	return r.findPendingCategoryRequest(ctx, vendorID, categoryID)
+}
+
+func (r *stubRepo) DecideCategoryRequest(ctx context.Context, requestID string, decision adminModel.CategoryDecision) error {
+	return r.decideCategoryRequest(ctx, requestID, decision)
+}
+
+func (r *stubRepo) GetCategoryUsage(ctx context.Context, period adminModel.Period) ([]adminModel.CategoryUsage, error) {
+	return r.getCategoryUsage(ctx, period)
+}
+
+func (r *stubRepo) GetCategory(ctx context.Context, categoryID string) (*adminModel.Category, error) {
+	return r.getCategory(ctx, categoryID)
+}
+
+func (r *stubRepo) RevokeVendorCategory(ctx context.Context, revocation *adminModel.CategoryRevocation, categoryName, adminEmail string) ([]adminModel.Booking, error) {
+	return r.revokeVendorCategory(ctx, revocation, categoryName, adminEmail)
+}
+
+func (r *stubRepo) GetVendorStanding(ctx context.Context, vendorID string) (*adminModel.VendorStanding, error) {
+	return r.getVendorStanding(ctx, vendorID)
+}
+
+func (r *stubRepo) RecordAutoApprovalEvaluation(ctx context.Context, evaluation *adminModel.AutoApprovalEvaluation) error {
+	return r.recordAutoApprovalEvaluation(ctx, evaluation)
+}
+
+func (r *stubRepo) AutoApproveCategoryRequest(ctx context.Context, decision adminModel.CategoryDecision, evaluation *adminModel.AutoApprovalEvaluation) error {
+	return r.autoApproveCategoryRequest(ctx, decision, evaluation)
+}
+
+func (r *stubRepo) ReconcileDashboardCounters(ctx context.Context) (before, after *adminModel.DashboardStats, err error) {
+	return r.reconcileDashboardCounters(ctx)
+}
+
+func (r *stubRepo) GetTimeSeries(ctx context.Context, period adminModel.Period, granularity string) ([]adminModel.TimeSeriesPoint, error) {
+	return r.getTimeSeries(ctx, period, granularity)
+}
+
+func (r *stubRepo) GetFilteredDashboard(ctx context.Context, filter adminModel.DashboardFilter) (*adminModel.DashboardStats, error) {
+	return r.getFilteredDashboard(ctx, filter)
+}
+
+func (r *stubRepo) GetClientCohorts(ctx context.Context, period adminModel.Period, months int, now time.Time) ([]adminModel.ClientCohortCell, error) {
+	return r.getClientCohorts(ctx, period, months, now)
+}
+
+func (r *stubRepo) FindAmountSpikes(ctx context.Context, period adminModel.Period, settings *adminModel.AnomalySettings) ([]adminModel.AnomalyCandidate, error) {
+	return r.findAmountSpikes(ctx, period, settings)
+}
+
+func (r *stubRepo) FindMovementBursts(ctx context.Context, period adminModel.Period, sources []string, window time.Duration, threshold int) ([]adminModel.AnomalyCandidate, error) {
+	return r.findMovementBursts(ctx, period, sources, window, threshold)
+}
+
+func newTestService(repo repository.AdminRepository) *AdminService {
+	return &AdminService{AdminRepo: repo, log: nopLogger{}}
+}
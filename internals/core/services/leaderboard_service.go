@@ -0,0 +1,143 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	pb "github.com/AthulKrishna2501/proto-repo/admin"
+	adminModel "github.com/AthulKrishna2501/zyra-admin-service/internals/core/models"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// maxLeaderboardExportRows bounds a CSV export, which is not paginated.
+const maxLeaderboardExportRows = 10000
+
+var leaderboardCSVHeader = []string{
+	"rank", "user_id", "first_name", "last_name", "bookings", "completed_bookings", "amount", "completion_rate",
+}
+
+func (s *AdminService) GetLeaderboard(ctx context.Context, req *pb.GetLeaderboardRequest) (*pb.GetLeaderboardResponse, error) {
+	filter, err := leaderboardFilter(req)
+	if err != nil {
+		return nil, err
+	}
+
+	filter.Limit, filter.Offset = pageBounds(req.Page, req.PageSize)
+
+	entries, total, err := s.AdminRepo.GetLeaderboard(ctx, filter)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Failed to build leaderboard: %v", err)
+	}
+
+	var pbEntries []*pb.LeaderboardEntry
+	for _, e := range entries {
+		pbEntries = append(pbEntries, &pb.LeaderboardEntry{
+			Rank:              int32(e.Rank),
+			UserId:            e.UserID.String(),
+			Name:              strings.TrimSpace(e.FirstName + " " + e.LastName),
+			Bookings:          int32(e.Bookings),
+			CompletedBookings: int32(e.CompletedBookings),
+			Amount:            e.Amount,
+			CompletionRate:    e.CompletionRate,
+		})
+	}
+
+	return &pb.GetLeaderboardResponse{
+		Board:   filter.Board,
+		RankBy:  filter.RankBy,
+		Entries: pbEntries,
+		Total:   int32(total),
+	}, nil
+}
+
+func (s *AdminService) ExportLeaderboard(ctx context.Context, req *pb.GetLeaderboardRequest) (*pb.ExportLeaderboardResponse, error) {
+	filter, err := leaderboardFilter(req)
+	if err != nil {
+		return nil, err
+	}
+
+	filter.Limit = maxLeaderboardExportRows
+
+	entries, _, err := s.AdminRepo.GetLeaderboard(ctx, filter)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Failed to build leaderboard: %v", err)
+	}
+
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+	if err := w.Write(leaderboardCSVHeader); err != nil {
+		return nil, status.Errorf(codes.Internal, "Failed to encode leaderboard: %v", err)
+	}
+	for _, e := range entries {
+		err := w.Write([]string{
+			strconv.FormatInt(e.Rank, 10),
+			e.UserID.String(),
+			e.FirstName,
+			e.LastName,
+			strconv.FormatInt(e.Bookings, 10),
+			strconv.FormatInt(e.CompletedBookings, 10),
+			strconv.FormatInt(e.Amount, 10),
+			strconv.FormatFloat(e.CompletionRate, 'f', 4, 64),
+		})
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "Failed to encode leaderboard: %v", err)
+		}
+	}
+	w.Flush()
+	if err := w.Error(); err != nil {
+		return nil, status.Errorf(codes.Internal, "Failed to encode leaderboard: %v", err)
+	}
+
+	return &pb.ExportLeaderboardResponse{
+		FileName:    fmt.Sprintf("%s-by-%s-%s.csv", filter.Board, filter.RankBy, time.Now().UTC().Format("20060102")),
+		ContentType: "text/csv",
+		Data:        buf.Bytes(),
+	}, nil
+}
+
+func leaderboardFilter(req *pb.GetLeaderboardRequest) (adminModel.LeaderboardFilter, error) {
+	period, err := analyticsPeriod(req.From, req.To)
+	if err != nil {
+		return adminModel.LeaderboardFilter{}, err
+	}
+
+	filter := adminModel.LeaderboardFilter{
+		Board:       req.Board,
+		RankBy:      req.RankBy,
+		Period:      period,
+		MinBookings: int(req.MinBookings),
+	}
+	if filter.MinBookings < 1 {
+		filter.MinBookings = 1
+	}
+
+	switch filter.Board {
+	case "", adminModel.LeaderboardVendors:
+		filter.Board = adminModel.LeaderboardVendors
+		switch filter.RankBy {
+		case "":
+			filter.RankBy = adminModel.RankByRevenue
+		case adminModel.RankByRevenue, adminModel.RankByBookings, adminModel.RankByCompletionRate:
+		default:
+			return filter, status.Errorf(codes.InvalidArgument, "Invalid ranking for vendors. Allowed values: 'revenue', 'bookings', 'completion_rate'")
+		}
+	case adminModel.LeaderboardClients:
+		switch filter.RankBy {
+		case "":
+			filter.RankBy = adminModel.RankBySpend
+		case adminModel.RankBySpend, adminModel.RankByBookings:
+		default:
+			return filter, status.Errorf(codes.InvalidArgument, "Invalid ranking for clients. Allowed values: 'spend', 'bookings'")
+		}
+	default:
+		return filter, status.Errorf(codes.InvalidArgument, "Invalid board. Allowed values: 'vendors', 'clients'")
+	}
+
+	return filter, nil
+}
@@ -0,0 +1,74 @@
+package services
+
+import (
+	"testing"
+
+	pb "github.com/AthulKrishna2501/proto-repo/admin"
+	adminModel "github.com/AthulKrishna2501/zyra-admin-service/internals/core/models"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestLeaderboardFilter(t *testing.T) {
+	tests := []struct {
+		name            string
+		req             *pb.GetLeaderboardRequest
+		wantBoard       string
+		wantRankBy      string
+		wantMinBookings int
+		wantCode        codes.Code
+	}{
+		{
+			name:            "defaults to vendors by revenue",
+			req:             &pb.GetLeaderboardRequest{},
+			wantBoard:       adminModel.LeaderboardVendors,
+			wantRankBy:      adminModel.RankByRevenue,
+			wantMinBookings: 1,
+		},
+		{
+			name:            "clients default to spend",
+			req:             &pb.GetLeaderboardRequest{Board: adminModel.LeaderboardClients, MinBookings: 3},
+			wantBoard:       adminModel.LeaderboardClients,
+			wantRankBy:      adminModel.RankBySpend,
+			wantMinBookings: 3,
+		},
+		{
+			name:            "vendors by completion rate",
+			req:             &pb.GetLeaderboardRequest{Board: adminModel.LeaderboardVendors, RankBy: adminModel.RankByCompletionRate},
+			wantBoard:       adminModel.LeaderboardVendors,
+			wantRankBy:      adminModel.RankByCompletionRate,
+			wantMinBookings: 1,
+		},
+		{
+			name:     "clients cannot rank by completion rate",
+			req:      &pb.GetLeaderboardRequest{Board: adminModel.LeaderboardClients, RankBy: adminModel.RankByCompletionRate},
+			wantCode: codes.InvalidArgument,
+		},
+		{
+			name:     "vendors cannot rank by spend",
+			req:      &pb.GetLeaderboardRequest{RankBy: adminModel.RankBySpend},
+			wantCode: codes.InvalidArgument,
+		},
+		{
+			name:     "unknown board",
+			req:      &pb.GetLeaderboardRequest{Board: "admins"},
+			wantCode: codes.InvalidArgument,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			filter, err := leaderboardFilter(tt.req)
+			if got := status.Code(err); got != tt.wantCode {
+				t.Fatalf("code = %v, want %v (%v)", got, tt.wantCode, err)
+			}
+			if err != nil {
+				return
+			}
+			if filter.Board != tt.wantBoard || filter.RankBy != tt.wantRankBy || filter.MinBookings != tt.wantMinBookings {
+				t.Errorf("filter = %s/%s/%d, want %s/%s/%d", filter.Board, filter.RankBy, filter.MinBookings,
+					tt.wantBoard, tt.wantRankBy, tt.wantMinBookings)
+			}
+		})
+	}
+}
@@ -0,0 +1,57 @@
+package services
+
+import (
+	pb "github.com/AthulKrishna2501/proto-repo/admin"
+	"github.com/AthulKrishna2501/zyra-admin-service/internals/core/events"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// liveFeedBuffer is how many events a subscriber may fall behind before it
+// starts missing them.
+const liveFeedBuffer = 256
+
+// StreamLiveFeed pushes new bookings, category requests, fund release
+// requests and admin wallet transactions to the caller as they are
+// committed, until the caller goes away. Types narrows the feed; it is
+// empty for everything.
+func (s *AdminService) StreamLiveFeed(req *pb.StreamLiveFeedRequest, stream pb.AdminService_StreamLiveFeedServer) error {
+	if s.feed == nil {
+		return status.Errorf(codes.Unavailable, "Live feed is not available")
+	}
+
+	filter, err := events.ParseFilter(req.Types)
+	if err != nil {
+		return status.Errorf(codes.InvalidArgument, "Invalid event types: %v", err)
+	}
+
+	feed, unsubscribe := s.feed.Subscribe(liveFeedBuffer)
+	defer unsubscribe()
+
+	s.log.Info("Admin Service: live feed subscriber connected", req.Types)
+
+	for {
+		select {
+		case <-stream.Context().Done():
+			s.log.Info("Admin Service: live feed subscriber disconnected")
+			return nil
+		case event, ok := <-feed:
+			if !ok {
+				return nil
+			}
+			if !filter.Match(event) {
+				continue
+			}
+
+			if err := stream.Send(&pb.LiveFeedEvent{
+				Type:       event.Type,
+				Id:         event.ID,
+				OccurredAt: timestamppb.New(event.OccurredAt),
+				Data:       string(event.Data),
+			}); err != nil {
+				return err
+			}
+		}
+	}
+}
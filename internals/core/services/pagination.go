@@ -0,0 +1,22 @@
+package services
+
+const (
+	defaultPageSize = 20
+	maxPageSize     = 100
+)
+
+// pageBounds turns the 1-based page and page size from a request into a
+// limit and offset, applying the default and maximum page sizes.
+func pageBounds(page, pageSize int32) (limit, offset int) {
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 {
+		pageSize = defaultPageSize
+	}
+	if pageSize > maxPageSize {
+		pageSize = maxPageSize
+	}
+
+	return int(pageSize), int((page - 1) * pageSize)
+}
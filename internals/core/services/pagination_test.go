@@ -0,0 +1,27 @@
+package services
+
+import "testing"
+
+func TestPageBounds(t *testing.T) {
+	tests := []struct {
+		name       string
+		page, size int32
+		wantLimit  int
+		wantOffset int
+	}{
+		{"defaults", 0, 0, defaultPageSize, 0},
+		{"first page", 1, 10, 10, 0},
+		{"third page", 3, 10, 10, 20},
+		{"negative page", -2, 10, 10, 0},
+		{"size capped", 2, 500, maxPageSize, maxPageSize},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			limit, offset := pageBounds(tt.page, tt.size)
+			if limit != tt.wantLimit || offset != tt.wantOffset {
+				t.Errorf("pageBounds(%d, %d) = %d, %d, want %d, %d", tt.page, tt.size, limit, offset, tt.wantLimit, tt.wantOffset)
+			}
+		})
+	}
+}
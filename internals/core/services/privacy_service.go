@@ -0,0 +1,81 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	pb "github.com/AthulKrishna2501/proto-repo/admin"
+	adminModel "github.com/AthulKrishna2501/zyra-admin-service/internals/core/models"
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"gorm.io/gorm"
+)
+
+func (s *AdminService) ExportUserData(ctx context.Context, req *pb.ExportUserDataRequest) (*pb.ExportUserDataResponse, error) {
+	if _, err := uuid.Parse(req.UserId); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "Invalid user ID: %v", err)
+	}
+
+	export, err := s.AdminRepo.ExportUserData(ctx, req.UserId)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, status.Errorf(codes.NotFound, "User %s not found", req.UserId)
+	} else if err != nil {
+		return nil, status.Errorf(codes.Internal, "Failed to export user data: %v", err)
+	}
+
+	data, err := json.MarshalIndent(export, "", "  ")
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Failed to encode user data: %v", err)
+	}
+
+	s.log.Info("Admin Service: user data exported", req.UserId)
+
+	return &pb.ExportUserDataResponse{
+		FileName:    fmt.Sprintf("user-data-%s-%s.json", req.UserId, export.GeneratedAt.Format("20060102")),
+		ContentType: "application/json",
+		Data:        data,
+	}, nil
+}
+
+func (s *AdminService) EraseUserData(ctx context.Context, req *pb.EraseUserDataRequest) (*pb.EraseUserDataResponse, error) {
+	userUUID, err := uuid.Parse(req.UserId)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "Invalid user ID: %v", err)
+	}
+
+	if req.RequestedBy == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "RequestedBy is required")
+	}
+
+	role, err := s.AdminRepo.GetUserRole(ctx, req.UserId)
+	if err != nil {
+		return nil, status.Errorf(codes.NotFound, "User not found: %v", err)
+	}
+	if role == adminModel.RoleAdmin {
+		return nil, status.Errorf(codes.FailedPrecondition, "Admin accounts cannot be erased")
+	}
+
+	erasure := &adminModel.DataErasure{
+		UserID:      userUUID,
+		RequestedBy: req.RequestedBy,
+		Reason:      req.Reason,
+	}
+	if err := s.AdminRepo.EraseUserData(ctx, erasure); err != nil {
+		return nil, status.Errorf(codes.Internal, "Failed to erase user data: %v", err)
+	}
+
+	if err := s.redisClient.SAdd(ctx, blockedUsersKey, req.UserId).Err(); err != nil {
+		s.log.Error("Admin Service: failed to add erased user to block list", req.UserId, err)
+	}
+
+	s.log.Info("Admin Service: user data erased", req.UserId, req.RequestedBy)
+
+	return &pb.EraseUserDataResponse{
+		Message:  fmt.Sprintf("Personal data of user %s has been erased", req.UserId),
+		ErasedAt: timestamppb.New(erasure.CreatedAt),
+	}, nil
+}
@@ -0,0 +1,136 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	pb "github.com/AthulKrishna2501/proto-repo/admin"
+	adminModel "github.com/AthulKrishna2501/zyra-admin-service/internals/core/models"
+	"github.com/AthulKrishna2501/zyra-admin-service/internals/core/repository"
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"gorm.io/gorm"
+)
+
+func (s *AdminService) ChangeUserRole(ctx context.Context, req *pb.ChangeUserRoleRequest) (*pb.ChangeUserRoleResponse, error) {
+	userUUID, err := uuid.Parse(req.UserId)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "Invalid user ID: %v", err)
+	}
+
+	if req.ChangedBy == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "ChangedBy is required")
+	}
+
+	switch req.Role {
+	case adminModel.RoleClient, adminModel.RoleVendor, adminModel.RoleAdmin:
+	default:
+		return nil, status.Errorf(codes.InvalidArgument, "Invalid role. Allowed values: 'client', 'vendor', 'admin'")
+	}
+
+	if req.Role == adminModel.RoleAdmin && strings.TrimSpace(req.Reason) == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "A reason is required when granting admin access")
+	}
+
+	currentRole, err := s.AdminRepo.GetUserRole(ctx, req.UserId)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, status.Errorf(codes.NotFound, "User not found: %v", err)
+	} else if err != nil {
+		return nil, status.Errorf(codes.Internal, "Failed to fetch user role: %v", err)
+	}
+
+	if currentRole == req.Role {
+		return nil, status.Errorf(codes.AlreadyExists, "User %s already has role %s", req.UserId, req.Role)
+	}
+
+	removeVendorData := false
+
+	if currentRole == adminModel.RoleVendor {
+		openBookings, err := s.AdminRepo.CountOpenVendorBookings(ctx, req.UserId)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "Failed to count open bookings: %v", err)
+		}
+		if openBookings > 0 {
+			return nil, status.Errorf(codes.FailedPrecondition, "Vendor has %d open bookings, they must be completed or cancelled first", openBookings)
+		}
+
+		categories, err := s.AdminRepo.CountVendorCategories(ctx, req.UserId)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "Failed to count vendor categories: %v", err)
+		}
+		if categories > 0 && !req.Force {
+			return nil, status.Errorf(codes.FailedPrecondition, "Vendor belongs to %d categories, set force to remove them", categories)
+		}
+
+		removeVendorData = true
+	}
+
+	change := &adminModel.RoleChange{
+		UserID:       userUUID,
+		PreviousRole: currentRole,
+		NewRole:      req.Role,
+		Reason:       req.Reason,
+		ChangedBy:    req.ChangedBy,
+	}
+	err = s.AdminRepo.ChangeUserRole(ctx, change, removeVendorData)
+	switch {
+	case errors.Is(err, repository.ErrLastAdmin):
+		return nil, status.Errorf(codes.FailedPrecondition, "Cannot remove the last admin")
+	case errors.Is(err, repository.ErrRoleChanged):
+		return nil, status.Errorf(codes.Aborted, "Failed to change role: %v", err)
+	case err != nil:
+		return nil, status.Errorf(codes.Internal, "Failed to change role: %v", err)
+	}
+
+	s.log.Info("Admin Service: user role changed", req.UserId, currentRole, req.Role, req.ChangedBy)
+
+	return &pb.ChangeUserRoleResponse{
+		Message:      fmt.Sprintf("User %s is now %s", req.UserId, req.Role),
+		PreviousRole: currentRole,
+		Role:         req.Role,
+		SideEffects:  roleChangeSideEffects(change),
+	}, nil
+}
+
+func (s *AdminService) ListRoleChanges(ctx context.Context, req *pb.ListRoleChangesRequest) (*pb.ListRoleChangesResponse, error) {
+	limit, offset := pageBounds(req.Page, req.PageSize)
+
+	changes, total, err := s.AdminRepo.ListRoleChanges(ctx, req.UserId, limit, offset)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Failed to fetch role changes: %v", err)
+	}
+
+	var pbChanges []*pb.RoleChange
+	for _, change := range changes {
+		pbChanges = append(pbChanges, &pb.RoleChange{
+			ChangeId:     change.ChangeID.String(),
+			UserId:       change.UserID.String(),
+			PreviousRole: change.PreviousRole,
+			NewRole:      change.NewRole,
+			Reason:       change.Reason,
+			ChangedBy:    change.ChangedBy,
+			CreatedAt:    timestamppb.New(change.CreatedAt),
+		})
+	}
+
+	return &pb.ListRoleChangesResponse{
+		Changes: pbChanges,
+		Total:   int32(total),
+	}, nil
+}
+
+// roleChangeSideEffects describes the vendor data a role change removed.
+func roleChangeSideEffects(change *adminModel.RoleChange) []string {
+	var sideEffects []string
+	if change.RemovedCategories > 0 {
+		sideEffects = append(sideEffects, fmt.Sprintf("removed %d vendor category memberships", change.RemovedCategories))
+	}
+	if change.DroppedRequests > 0 {
+		sideEffects = append(sideEffects, fmt.Sprintf("dropped %d pending category requests", change.DroppedRequests))
+	}
+	return sideEffects
+}
@@ -0,0 +1,90 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+
+	pb "github.com/AthulKrishna2501/proto-repo/admin"
+	adminModel "github.com/AthulKrishna2501/zyra-admin-service/internals/core/models"
+	"github.com/AthulKrishna2501/zyra-admin-service/internals/core/repository"
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"gorm.io/gorm"
+)
+
+func TestRoleChangeSideEffects(t *testing.T) {
+	tests := []struct {
+		name   string
+		change adminModel.RoleChange
+		want   []string
+	}{
+		{
+			name: "nothing removed",
+		},
+		{
+			name:   "memberships only",
+			change: adminModel.RoleChange{RemovedCategories: 2},
+			want:   []string{"removed 2 vendor category memberships"},
+		},
+		{
+			name:   "requests only",
+			change: adminModel.RoleChange{DroppedRequests: 1},
+			want:   []string{"dropped 1 pending category requests"},
+		},
+		{
+			name:   "both",
+			change: adminModel.RoleChange{RemovedCategories: 3, DroppedRequests: 4},
+			want:   []string{"removed 3 vendor category memberships", "dropped 4 pending category requests"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := roleChangeSideEffects(&tt.change); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("roleChangeSideEffects() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestChangeUserRoleErrors(t *testing.T) {
+	tests := []struct {
+		name      string
+		roleErr   error
+		changeErr error
+		want      codes.Code
+	}{
+		{name: "changed", want: codes.OK},
+		{name: "unknown user", roleErr: fmt.Errorf("user_id x: %w", gorm.ErrRecordNotFound), want: codes.NotFound},
+		{name: "role lookup fails", roleErr: errors.New("connection reset"), want: codes.Internal},
+		{name: "last admin", changeErr: repository.ErrLastAdmin, want: codes.FailedPrecondition},
+		{name: "changed concurrently", changeErr: fmt.Errorf("%w: user x no longer has role client", repository.ErrRoleChanged), want: codes.Aborted},
+		{name: "database failure", changeErr: errors.New("connection reset"), want: codes.Internal},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &stubRepo{
+				getUserRole: func(context.Context, string) (string, error) {
+					return adminModel.RoleClient, tt.roleErr
+				},
+				changeUserRole: func(context.Context, *adminModel.RoleChange, bool) error {
+					return tt.changeErr
+				},
+			}
+
+			_, err := newTestService(repo).ChangeUserRole(context.Background(), &pb.ChangeUserRoleRequest{
+				UserId:    uuid.NewString(),
+				Role:      adminModel.RoleVendor,
+				ChangedBy: "admin@zyra.com",
+			})
+			if got := status.Code(err); got != tt.want {
+				t.Errorf("ChangeUserRole() = %v, want %v (%v)", got, tt.want, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,217 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	pb "github.com/AthulKrishna2501/proto-repo/admin"
+	adminModel "github.com/AthulKrishna2501/zyra-admin-service/internals/core/models"
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	blockedUsersKey = "blocked_users"
+	maxBulkUsers    = 1000
+)
+
+func (s *AdminService) BulkBlockUsers(ctx context.Context, req *pb.BulkBlockUnblockUsersRequest) (*pb.BulkBlockUnblockUsersResponse, error) {
+	return s.bulkSetBlocked(ctx, req, true)
+}
+
+func (s *AdminService) BulkUnblockUsers(ctx context.Context, req *pb.BulkBlockUnblockUsersRequest) (*pb.BulkBlockUnblockUsersResponse, error) {
+	return s.bulkSetBlocked(ctx, req, false)
+}
+
+// bulkSetBlocked resolves the target users from the explicit ID list and/or
+// the filter and blocks or unblocks them through setUsersBlocked.
+func (s *AdminService) bulkSetBlocked(ctx context.Context, req *pb.BulkBlockUnblockUsersRequest, blocked bool) (*pb.BulkBlockUnblockUsersResponse, error) {
+	action := "block"
+	if !blocked {
+		action = "unblock"
+	}
+
+	if len(req.UserIds) == 0 && req.Filter == nil {
+		return nil, status.Errorf(codes.InvalidArgument, "Either user IDs or a filter is required")
+	}
+
+	var matched []string
+	if req.Filter != nil {
+		currentlyBlocked := !blocked
+		filter := adminModel.UserFilter{
+			Role:      req.Filter.Role,
+			Email:     req.Filter.Email,
+			IsBlocked: &currentlyBlocked,
+		}
+		if req.Filter.CreatedAfter != nil {
+			filter.CreatedAfter = req.Filter.CreatedAfter.AsTime()
+		}
+		if req.Filter.CreatedBefore != nil {
+			filter.CreatedBefore = req.Filter.CreatedBefore.AsTime()
+		}
+
+		var err error
+		matched, err = s.AdminRepo.FindUserIDs(ctx, filter)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "Failed to resolve users from filter: %v", err)
+		}
+	}
+
+	userIDs := uniqueUserIDs(req.UserIds, matched)
+	if len(userIDs) > maxBulkUsers {
+		return nil, status.Errorf(codes.InvalidArgument, "Cannot %s more than %d users at once, got %d", action, maxBulkUsers, len(userIDs))
+	}
+
+	results, err := s.setUsersBlocked(ctx, userIDs, blocked)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Failed to %s users: %v", action, err)
+	}
+
+	resp := &pb.BulkBlockUnblockUsersResponse{}
+	for _, id := range userIDs {
+		result, ok := results[id]
+		if !ok {
+			result = &adminModel.UserActionResult{UserID: id, Message: "user not found or is an admin"}
+		}
+
+		if result.Success {
+			resp.Succeeded++
+		} else {
+			resp.Failed++
+		}
+
+		resp.Results = append(resp.Results, &pb.UserActionResult{
+			UserId:  result.UserID,
+			Success: result.Success,
+			Message: result.Message,
+		})
+	}
+
+	s.log.Info("Admin Service: bulk user update finished", action, resp.Succeeded, resp.Failed)
+
+	return resp, nil
+}
+
+// setUsersBlocked updates users.is_blocked in one statement and then
+// mirrors the change into the Redis block list through a single pipeline.
+// Users whose Redis update fails are restored to their previous is_blocked
+// value so both stores agree; users that already had the requested value
+// are left as they were. Users that were not found or are admins have no
+// result.
+func (s *AdminService) setUsersBlocked(ctx context.Context, userIDs []string, blocked bool) (map[string]*adminModel.UserActionResult, error) {
+	action := "block"
+	if !blocked {
+		action = "unblock"
+	}
+
+	results := make(map[string]*adminModel.UserActionResult, len(userIDs))
+	var validIDs []string
+	for _, id := range userIDs {
+		if _, err := uuid.Parse(id); err != nil {
+			results[id] = &adminModel.UserActionResult{UserID: id, Message: "invalid user ID"}
+			continue
+		}
+		validIDs = append(validIDs, id)
+	}
+
+	updated, err := s.AdminRepo.SetUsersBlocked(ctx, validIDs, blocked)
+	if err != nil {
+		return nil, err
+	}
+
+	wasBlocked := make(map[string]bool, len(updated))
+	pipe := s.redisClient.Pipeline()
+	cmds := make(map[string]*redis.IntCmd, len(updated))
+	for _, u := range updated {
+		wasBlocked[u.UserID] = u.WasBlocked
+		if blocked {
+			cmds[u.UserID] = pipe.SAdd(ctx, blockedUsersKey, u.UserID)
+		} else {
+			cmds[u.UserID] = pipe.SRem(ctx, blockedUsersKey, u.UserID)
+		}
+	}
+	// A server error is recorded on the command that caused it and Exec only
+	// reports the first one. Anything else, such as a refused connection,
+	// is not set on the commands and fails the whole pipeline.
+	var pipelineErr error
+	if len(cmds) > 0 {
+		if _, err := pipe.Exec(ctx); err != nil {
+			var replyErr redis.Error
+			if !errors.As(err, &replyErr) {
+				pipelineErr = err
+			}
+		}
+	}
+
+	var revert []string
+	for id, cmd := range cmds {
+		err := cmd.Err()
+		if err == nil {
+			err = pipelineErr
+		}
+		if err != nil {
+			if wasBlocked[id] != blocked {
+				revert = append(revert, id)
+			}
+			results[id] = &adminModel.UserActionResult{UserID: id, Message: fmt.Sprintf("failed to update block list: %v", err)}
+			continue
+		}
+		results[id] = &adminModel.UserActionResult{UserID: id, Success: true, Message: fmt.Sprintf("user has been %sed", action)}
+	}
+
+	if len(revert) > 0 {
+		if _, err := s.AdminRepo.SetUsersBlocked(ctx, revert, !blocked); err != nil {
+			s.log.Error("Admin Service: failed to roll back is_blocked for users", revert, err)
+		}
+	}
+
+	return results, nil
+}
+
+// setUserBlocked blocks or unblocks a single user the same way as the bulk
+// path, turning its per-user result into an error.
+func (s *AdminService) setUserBlocked(ctx context.Context, userID string, blocked bool) error {
+	action := "block"
+	if !blocked {
+		action = "unblock"
+	}
+
+	if _, err := uuid.Parse(userID); err != nil {
+		return status.Errorf(codes.InvalidArgument, "Invalid user ID: %v", err)
+	}
+
+	results, err := s.setUsersBlocked(ctx, []string{userID}, blocked)
+	if err != nil {
+		return status.Errorf(codes.Internal, "Failed to %s user: %v", action, err)
+	}
+
+	result, ok := results[userID]
+	if !ok {
+		return status.Errorf(codes.NotFound, "User %s not found or is an admin", userID)
+	}
+	if !result.Success {
+		return status.Errorf(codes.Internal, "Failed to %s user: %s", action, result.Message)
+	}
+
+	return nil
+}
+
+// uniqueUserIDs merges the explicit IDs and the IDs matched by a filter,
+// keeping the first occurrence of each.
+func uniqueUserIDs(lists ...[]string) []string {
+	var ids []string
+	seen := make(map[string]bool)
+	for _, list := range lists {
+		for _, id := range list {
+			if !seen[id] {
+				seen[id] = true
+				ids = append(ids, id)
+			}
+		}
+	}
+
+	return ids
+}
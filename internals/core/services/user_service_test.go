@@ -0,0 +1,120 @@
+package services
+
+import (
+	"context"
+	"reflect"
+	"sort"
+	"testing"
+	"time"
+
+	adminModel "github.com/AthulKrishna2501/zyra-admin-service/internals/core/models"
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestUniqueUserIDs(t *testing.T) {
+	tests := []struct {
+		name  string
+		lists [][]string
+		want  []string
+	}{
+		{"empty", nil, nil},
+		{"explicit only", [][]string{{"a", "b"}}, []string{"a", "b"}},
+		{"duplicates within a list", [][]string{{"a", "b", "a"}}, []string{"a", "b"}},
+		{"filter overlaps explicit", [][]string{{"a", "b"}, {"b", "c"}}, []string{"a", "b", "c"}},
+		{"filter only", [][]string{nil, {"c", "c"}}, []string{"c"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := uniqueUserIDs(tt.lists...); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("uniqueUserIDs() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSetUserBlockedRejectsInvalidID(t *testing.T) {
+	s := &AdminService{}
+
+	for _, blocked := range []bool{true, false} {
+		err := s.setUserBlocked(context.Background(), "not-a-uuid", blocked)
+		if status.Code(err) != codes.InvalidArgument {
+			t.Errorf("setUserBlocked(blocked=%v) code = %v, want %v", blocked, status.Code(err), codes.InvalidArgument)
+		}
+	}
+}
+
+// TestSetUsersBlockedRollback points the service at a Redis that refuses
+// connections, so every block list update fails and the database change
+// has to be undone for the users it actually changed.
+func TestSetUsersBlockedRollback(t *testing.T) {
+	changed, already := uuid.NewString(), uuid.NewString()
+
+	tests := []struct {
+		name    string
+		blocked bool
+		updated []adminModel.BlockUpdate
+		want    []string
+	}{
+		{
+			name:    "block restores only users that were unblocked",
+			blocked: true,
+			updated: []adminModel.BlockUpdate{{UserID: changed}, {UserID: already, WasBlocked: true}},
+			want:    []string{changed},
+		},
+		{
+			name:    "unblock restores only users that were blocked",
+			blocked: false,
+			updated: []adminModel.BlockUpdate{{UserID: changed, WasBlocked: true}, {UserID: already}},
+			want:    []string{changed},
+		},
+		{
+			name:    "nothing changed",
+			blocked: true,
+			updated: []adminModel.BlockUpdate{{UserID: already, WasBlocked: true}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var reverted []string
+			calls := 0
+			repo := &stubRepo{
+				setUsersBlocked: func(_ context.Context, userIDs []string, blocked bool) ([]adminModel.BlockUpdate, error) {
+					calls++
+					if calls == 1 {
+						return tt.updated, nil
+					}
+					if blocked == tt.blocked {
+						t.Errorf("rollback set is_blocked = %v, want %v", blocked, !tt.blocked)
+					}
+					reverted = append(reverted, userIDs...)
+					return nil, nil
+				},
+			}
+
+			s := newTestService(repo)
+			s.redisClient = redis.NewClient(&redis.Options{Addr: "127.0.0.1:1", DialTimeout: 100 * time.Millisecond, MaxRetries: -1})
+			defer s.redisClient.Close()
+
+			ids := []string{changed, already}
+			results, err := s.setUsersBlocked(context.Background(), ids, tt.blocked)
+			if err != nil {
+				t.Fatalf("setUsersBlocked() error = %v", err)
+			}
+			for _, u := range tt.updated {
+				if results[u.UserID] == nil || results[u.UserID].Success {
+					t.Errorf("result for %s = %+v, want a failure", u.UserID, results[u.UserID])
+				}
+			}
+
+			sort.Strings(reverted)
+			if !reflect.DeepEqual(reverted, tt.want) {
+				t.Errorf("rolled back %v, want %v", reverted, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,161 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	pb "github.com/AthulKrishna2501/proto-repo/admin"
+	adminModel "github.com/AthulKrishna2501/zyra-admin-service/internals/core/models"
+	"github.com/AthulKrishna2501/zyra-admin-service/internals/core/repository"
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// categoryRevokedChannel is the Redis channel revocations are published on,
+// so the vendor and notification services can tell the vendor.
+const categoryRevokedChannel = "vendor_category_revoked"
+
+// CategoryRevocationHook is called after a vendor has been removed from a
+// category, with the bookings that were cancelled as a result.
+type CategoryRevocationHook func(ctx context.Context, revocation adminModel.CategoryRevocation, cancelled []adminModel.Booking)
+
+// OnCategoryRevoked registers a hook to run after every revocation. Hooks
+// run after the revocation is committed; they cannot undo it.
+func (s *AdminService) OnCategoryRevoked(hook CategoryRevocationHook) {
+	s.revocationHooks = append(s.revocationHooks, hook)
+}
+
+func (s *AdminService) RevokeVendorCategory(ctx context.Context, req *pb.RevokeVendorCategoryRequest) (*pb.RevokeVendorCategoryResponse, error) {
+	vendorUUID, err := uuid.Parse(req.VendorId)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "Invalid vendor ID: %v", err)
+	}
+	categoryUUID, err := uuid.Parse(req.CategoryId)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "Invalid category ID: %v", err)
+	}
+
+	reason := strings.TrimSpace(req.Reason)
+	if reason == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "A reason is required to revoke a category")
+	}
+
+	policy := req.BookingPolicy
+	switch policy {
+	case "":
+		policy = adminModel.RevokeBookingsKeep
+	case adminModel.RevokeBookingsKeep, adminModel.RevokeBookingsCancel, adminModel.RevokeBookingsRefuse:
+	default:
+		return nil, status.Errorf(codes.InvalidArgument, "Invalid booking policy. Allowed values: 'keep', 'cancel', 'refuse'")
+	}
+
+	category, err := s.AdminRepo.GetCategory(ctx, req.CategoryId)
+	if err != nil {
+		return nil, categoryError(err, req.CategoryId)
+	}
+
+	revocation := adminModel.CategoryRevocation{
+		VendorID:      vendorUUID,
+		CategoryID:    categoryUUID,
+		Reason:        reason,
+		BookingPolicy: policy,
+		RevokedBy:     req.RevokedBy,
+	}
+
+	cancelled, err := s.AdminRepo.RevokeVendorCategory(ctx, &revocation, category.CategoryName, adminWalletEmail)
+	switch {
+	case errors.Is(err, repository.ErrNotCategoryMember):
+		return nil, status.Errorf(codes.NotFound, "Vendor %s is not listed in category %s", req.VendorId, category.CategoryName)
+	case errors.Is(err, repository.ErrOpenBookings):
+		return nil, status.Errorf(codes.FailedPrecondition, "Vendor has %d open bookings in %s", revocation.BookingsAffected, category.CategoryName)
+	case err != nil:
+		return nil, status.Errorf(codes.Internal, "Failed to revoke category: %v", err)
+	}
+
+	s.log.Info("Admin Service: vendor category revoked", req.VendorId, req.CategoryId, policy)
+
+	s.notifyCategoryRevoked(ctx, revocation, cancelled)
+
+	refunds, refunded := refundTotals(cancelled)
+
+	message := fmt.Sprintf("Vendor removed from %s", category.CategoryName)
+	switch {
+	case len(cancelled) > 0:
+		message = fmt.Sprintf("%s, %d bookings cancelled, %d refunded for %d in total", message, len(cancelled), refunds, refunded)
+	case policy == adminModel.RevokeBookingsKeep && revocation.BookingsAffected > 0:
+		message = fmt.Sprintf("%s, %d open bookings will still be honoured", message, revocation.BookingsAffected)
+	}
+
+	return &pb.RevokeVendorCategoryResponse{
+		RevocationId:     revocation.RevocationID.String(),
+		BookingsAffected: int32(revocation.BookingsAffected),
+		RefundedAmount:   int64(refunded),
+		Message:          message,
+	}, nil
+}
+
+func (s *AdminService) ListVendorCategories(ctx context.Context, req *pb.ListVendorCategoriesRequest) (*pb.ListVendorCategoriesResponse, error) {
+	if _, err := uuid.Parse(req.VendorId); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "Invalid vendor ID: %v", err)
+	}
+
+	memberships, err := s.AdminRepo.GetVendorCategoryMemberships(ctx, req.VendorId)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Failed to fetch vendor categories: %v", err)
+	}
+
+	var categories []*pb.VendorCategoryMembership
+	for _, m := range memberships {
+		if m.Status == adminModel.MembershipRevoked && !req.IncludeRevoked {
+			continue
+		}
+
+		item := &pb.VendorCategoryMembership{
+			CategoryId:   m.CategoryID.String(),
+			CategoryName: m.CategoryName,
+			Status:       m.Status,
+			Reason:       m.Reason,
+		}
+		if m.Since != nil {
+			item.Since = timestamppb.New(*m.Since)
+		}
+
+		categories = append(categories, item)
+	}
+
+	return &pb.ListVendorCategoriesResponse{Categories: categories}, nil
+}
+
+// notifyCategoryRevoked publishes the revocation on Redis and runs the
+// registered hooks. Failures are logged only, the revocation already stands.
+func (s *AdminService) notifyCategoryRevoked(ctx context.Context, revocation adminModel.CategoryRevocation, cancelled []adminModel.Booking) {
+	var bookingIDs []string
+	for _, booking := range cancelled {
+		bookingIDs = append(bookingIDs, booking.BookingID.String())
+	}
+
+	payload, err := json.Marshal(map[string]interface{}{
+		"revocation_id":      revocation.RevocationID.String(),
+		"vendor_id":          revocation.VendorID.String(),
+		"category_id":        revocation.CategoryID.String(),
+		"reason":             revocation.Reason,
+		"booking_policy":     revocation.BookingPolicy,
+		"cancelled_bookings": bookingIDs,
+	})
+	if err != nil {
+		s.log.Error("Admin Service: failed to encode revocation notification", revocation.RevocationID.String(), err)
+	} else if s.redisClient != nil {
+		if err := s.redisClient.Publish(ctx, categoryRevokedChannel, payload).Err(); err != nil {
+			s.log.Error("Admin Service: failed to publish revocation notification", revocation.RevocationID.String(), err)
+		}
+	}
+
+	for _, hook := range s.revocationHooks {
+		hook(ctx, revocation, cancelled)
+	}
+}
@@ -0,0 +1,124 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	pb "github.com/AthulKrishna2501/proto-repo/admin"
+	adminModel "github.com/AthulKrishna2501/zyra-admin-service/internals/core/models"
+	"github.com/AthulKrishna2501/zyra-admin-service/internals/core/repository"
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestRevokeVendorCategory(t *testing.T) {
+	vendorID, categoryID := uuid.New().String(), uuid.New().String()
+	cancelled := []adminModel.Booking{
+		{BookingID: uuid.New(), Price: 500, Refunded: true},
+		{BookingID: uuid.New(), Price: 300},
+	}
+
+	tests := []struct {
+		name         string
+		req          *pb.RevokeVendorCategoryRequest
+		openBookings int64
+		revokeErr    error
+		wantCode     codes.Code
+		wantAffected int32
+		wantMessage  string
+		wantRefunded int64
+	}{
+		{
+			name:     "missing reason",
+			req:      &pb.RevokeVendorCategoryRequest{VendorId: vendorID, CategoryId: categoryID, Reason: "  "},
+			wantCode: codes.InvalidArgument,
+		},
+		{
+			name:     "unknown policy",
+			req:      &pb.RevokeVendorCategoryRequest{VendorId: vendorID, CategoryId: categoryID, Reason: "fraud", BookingPolicy: "pause"},
+			wantCode: codes.InvalidArgument,
+		},
+		{
+			name:         "keep by default",
+			req:          &pb.RevokeVendorCategoryRequest{VendorId: vendorID, CategoryId: categoryID, Reason: "fraud"},
+			openBookings: 2,
+			wantAffected: 2,
+			wantMessage:  "Vendor removed from Catering, 2 open bookings will still be honoured",
+		},
+		{
+			name:         "refuse with open bookings",
+			req:          &pb.RevokeVendorCategoryRequest{VendorId: vendorID, CategoryId: categoryID, Reason: "fraud", BookingPolicy: adminModel.RevokeBookingsRefuse},
+			openBookings: 1,
+			wantCode:     codes.FailedPrecondition,
+		},
+		{
+			name:         "cancel refunds paid bookings only",
+			req:          &pb.RevokeVendorCategoryRequest{VendorId: vendorID, CategoryId: categoryID, Reason: "fraud", BookingPolicy: adminModel.RevokeBookingsCancel},
+			openBookings: 2,
+			wantAffected: 2,
+			wantRefunded: 500,
+			wantMessage:  "Vendor removed from Catering, 2 bookings cancelled, 1 refunded for 500 in total",
+		},
+		{
+			name:      "not a member",
+			req:       &pb.RevokeVendorCategoryRequest{VendorId: vendorID, CategoryId: categoryID, Reason: "fraud"},
+			revokeErr: repository.ErrNotCategoryMember,
+			wantCode:  codes.NotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &stubRepo{
+				getCategory: func(context.Context, string) (*adminModel.Category, error) {
+					return &adminModel.Category{CategoryName: "Catering"}, nil
+				},
+				// Mirrors the repository: the open bookings are counted in the
+				// revocation transaction and the policy is applied there.
+				revokeVendorCategory: func(_ context.Context, revocation *adminModel.CategoryRevocation, _, _ string) ([]adminModel.Booking, error) {
+					if tt.revokeErr != nil {
+						return nil, tt.revokeErr
+					}
+					revocation.BookingsAffected = tt.openBookings
+					switch revocation.BookingPolicy {
+					case adminModel.RevokeBookingsRefuse:
+						if tt.openBookings > 0 {
+							return nil, repository.ErrOpenBookings
+						}
+					case adminModel.RevokeBookingsCancel:
+						revocation.RevocationID = uuid.New()
+						return cancelled, nil
+					}
+					revocation.RevocationID = uuid.New()
+					return nil, nil
+				},
+			}
+
+			s := newTestService(repo)
+			var hooked int
+			s.OnCategoryRevoked(func(context.Context, adminModel.CategoryRevocation, []adminModel.Booking) { hooked++ })
+
+			resp, err := s.RevokeVendorCategory(context.Background(), tt.req)
+			if got := status.Code(err); got != tt.wantCode {
+				t.Fatalf("RevokeVendorCategory() = %v, want %v (%v)", got, tt.wantCode, err)
+			}
+			if err != nil {
+				if hooked != 0 {
+					t.Errorf("hooks ran %d times for a failed revocation", hooked)
+				}
+				return
+			}
+
+			if resp.BookingsAffected != tt.wantAffected || resp.RefundedAmount != tt.wantRefunded {
+				t.Errorf("affected %d, refunded %d, want %d, %d", resp.BookingsAffected, resp.RefundedAmount, tt.wantAffected, tt.wantRefunded)
+			}
+			if resp.Message != tt.wantMessage {
+				t.Errorf("message = %q, want %q", resp.Message, tt.wantMessage)
+			}
+			if hooked != 1 {
+				t.Errorf("hooks ran %d times, want 1", hooked)
+			}
+		})
+	}
+}
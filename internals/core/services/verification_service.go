@@ -0,0 +1,315 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	pb "github.com/AthulKrishna2501/proto-repo/admin"
+	adminModel "github.com/AthulKrishna2501/zyra-admin-service/internals/core/models"
+	"github.com/AthulKrishna2501/zyra-admin-service/internals/core/repository"
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"gorm.io/gorm"
+)
+
+func (s *AdminService) SubmitVendorVerification(ctx context.Context, req *pb.SubmitVendorVerificationRequest) (*pb.SubmitVendorVerificationResponse, error) {
+	vendorUUID, err := uuid.Parse(req.VendorId)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "Invalid vendor ID: %v", err)
+	}
+
+	role, err := s.AdminRepo.GetUserRole(ctx, req.VendorId)
+	if err != nil {
+		return nil, status.Errorf(codes.NotFound, "Vendor not found: %v", err)
+	}
+	if role != adminModel.RoleVendor {
+		return nil, status.Errorf(codes.FailedPrecondition, "User %s is not a vendor", req.VendorId)
+	}
+
+	var hasIdentity, hasBusiness bool
+	var documents []adminModel.VerificationDocument
+	for _, doc := range req.Documents {
+		if doc.DocumentType == "" || doc.FileUrl == "" {
+			return nil, status.Errorf(codes.InvalidArgument, "Every document needs a document type and a file URL")
+		}
+
+		switch doc.Kind {
+		case adminModel.DocumentKindIdentity:
+			hasIdentity = true
+		case adminModel.DocumentKindBusiness:
+			hasBusiness = true
+		default:
+			return nil, status.Errorf(codes.InvalidArgument, "Invalid document kind %q. Allowed values: 'identity', 'business'", doc.Kind)
+		}
+
+		documents = append(documents, adminModel.VerificationDocument{
+			Kind:           doc.Kind,
+			DocumentType:   doc.DocumentType,
+			DocumentNumber: doc.DocumentNumber,
+			FileURL:        doc.FileUrl,
+		})
+	}
+
+	if !hasIdentity || !hasBusiness {
+		return nil, status.Errorf(codes.InvalidArgument, "At least one identity and one business document are required")
+	}
+
+	latest, err := s.AdminRepo.GetLatestVendorVerification(ctx, req.VendorId)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, status.Errorf(codes.Internal, "Failed to fetch existing verification: %v", err)
+	}
+	if latest != nil {
+		switch latest.Status {
+		case adminModel.VerificationStatusApproved:
+			return nil, status.Errorf(codes.AlreadyExists, "Vendor %s is already verified", req.VendorId)
+		case adminModel.VerificationStatusPending, adminModel.VerificationStatusInReview:
+			return nil, status.Errorf(codes.AlreadyExists, "Vendor %s already has a verification under review", req.VendorId)
+		}
+	}
+
+	verification := &adminModel.VendorVerification{
+		VendorID:  vendorUUID,
+		Status:    adminModel.VerificationStatusPending,
+		Documents: documents,
+	}
+	if err := s.AdminRepo.CreateVendorVerification(ctx, verification); err != nil {
+		return nil, status.Errorf(codes.Internal, "Failed to submit verification: %v", err)
+	}
+
+	s.log.Info("Admin Service: vendor verification submitted", req.VendorId, verification.VerificationID.String())
+
+	return &pb.SubmitVendorVerificationResponse{
+		VerificationId: verification.VerificationID.String(),
+		Status:         verification.Status,
+		Message:        "verification submitted for review",
+	}, nil
+}
+
+func (s *AdminService) ListVerificationQueue(ctx context.Context, req *pb.ListVerificationQueueRequest) (*pb.ListVerificationQueueResponse, error) {
+	statuses := []string{adminModel.VerificationStatusPending, adminModel.VerificationStatusInReview}
+	if req.Status != "" {
+		if !isVerificationStatus(req.Status) {
+			return nil, status.Errorf(codes.InvalidArgument, "Invalid verification status %q", req.Status)
+		}
+		statuses = []string{req.Status}
+	}
+
+	limit, offset := pageBounds(req.Page, req.PageSize)
+	items, total, err := s.AdminRepo.ListVerificationQueue(ctx, statuses, limit, offset)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Failed to fetch verification queue: %v", err)
+	}
+
+	var verifications []*pb.VendorVerification
+	for _, item := range items {
+		verifications = append(verifications, &pb.VendorVerification{
+			VerificationId: item.VerificationID.String(),
+			VendorId:       item.VendorID.String(),
+			VendorName:     strings.TrimSpace(item.VendorName),
+			Email:          item.Email,
+			Status:         item.Status,
+			DocumentCount:  item.DocumentCount,
+			CheckCount:     item.CheckCount,
+			SubmittedAt:    timestamppb.New(item.CreatedAt),
+		})
+	}
+
+	return &pb.ListVerificationQueueResponse{
+		Verifications: verifications,
+		Total:         int32(total),
+	}, nil
+}
+
+func (s *AdminService) GetVendorVerification(ctx context.Context, req *pb.GetVendorVerificationRequest) (*pb.GetVendorVerificationResponse, error) {
+	verificationID := req.VerificationId
+	if verificationID == "" {
+		if req.VendorId == "" {
+			return nil, status.Errorf(codes.InvalidArgument, "Verification ID or vendor ID is required")
+		}
+
+		latest, err := s.AdminRepo.GetLatestVendorVerification(ctx, req.VendorId)
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, status.Errorf(codes.NotFound, "No verification found for vendor %s", req.VendorId)
+		} else if err != nil {
+			return nil, status.Errorf(codes.Internal, "Failed to fetch verification: %v", err)
+		}
+		verificationID = latest.VerificationID.String()
+	}
+
+	verification, err := s.AdminRepo.GetVendorVerification(ctx, verificationID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, status.Errorf(codes.NotFound, "Verification %s not found", verificationID)
+	} else if err != nil {
+		return nil, status.Errorf(codes.Internal, "Failed to fetch verification: %v", err)
+	}
+
+	pbVerification := &pb.VendorVerification{
+		VerificationId: verification.VerificationID.String(),
+		VendorId:       verification.VendorID.String(),
+		Status:         verification.Status,
+		Reason:         verification.Reason,
+		ReviewedBy:     verification.ReviewedBy,
+		SubmittedAt:    timestamppb.New(verification.CreatedAt),
+		DocumentCount:  int32(len(verification.Documents)),
+		CheckCount:     int32(len(verification.Checks)),
+	}
+	if verification.ReviewedAt != nil {
+		pbVerification.ReviewedAt = timestamppb.New(*verification.ReviewedAt)
+	}
+
+	for _, doc := range verification.Documents {
+		pbVerification.Documents = append(pbVerification.Documents, &pb.VerificationDocument{
+			DocumentId:     doc.DocumentID.String(),
+			Kind:           doc.Kind,
+			DocumentType:   doc.DocumentType,
+			DocumentNumber: doc.DocumentNumber,
+			FileUrl:        doc.FileURL,
+		})
+	}
+
+	for _, check := range verification.Checks {
+		pbVerification.Checks = append(pbVerification.Checks, &pb.VerificationCheck{
+			CheckId:     check.CheckID.String(),
+			CheckType:   check.CheckType,
+			Passed:      check.Passed,
+			Notes:       check.Notes,
+			PerformedBy: check.PerformedBy,
+			CreatedAt:   timestamppb.New(check.CreatedAt),
+		})
+	}
+
+	return &pb.GetVendorVerificationResponse{Verification: pbVerification}, nil
+}
+
+func (s *AdminService) RecordVerificationCheck(ctx context.Context, req *pb.RecordVerificationCheckRequest) (*pb.RecordVerificationCheckResponse, error) {
+	if req.VerificationId == "" || req.CheckType == "" || req.PerformedBy == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "VerificationID, CheckType and PerformedBy are required")
+	}
+
+	verification, err := s.AdminRepo.GetVendorVerification(ctx, req.VerificationId)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, status.Errorf(codes.NotFound, "Verification %s not found", req.VerificationId)
+	} else if err != nil {
+		return nil, status.Errorf(codes.Internal, "Failed to fetch verification: %v", err)
+	}
+
+	if !isOpenVerification(verification.Status) {
+		return nil, status.Errorf(codes.FailedPrecondition, "Verification %s has already been %s", req.VerificationId, verification.Status)
+	}
+
+	check := &adminModel.VerificationCheck{
+		VerificationID: verification.VerificationID,
+		CheckType:      req.CheckType,
+		Passed:         req.Passed,
+		Notes:          req.Notes,
+		PerformedBy:    req.PerformedBy,
+	}
+	if err := s.AdminRepo.CreateVerificationCheck(ctx, check); err != nil {
+		return nil, status.Errorf(codes.Internal, "Failed to record verification check: %v", err)
+	}
+
+	return &pb.RecordVerificationCheckResponse{
+		CheckId: check.CheckID.String(),
+		Message: fmt.Sprintf("Check %s recorded", req.CheckType),
+	}, nil
+}
+
+func (s *AdminService) DecideVendorVerification(ctx context.Context, req *pb.DecideVendorVerificationRequest) (*pb.DecideVendorVerificationResponse, error) {
+	if req.VerificationId == "" || req.Status == "" || req.ReviewedBy == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "VerificationID, Status and ReviewedBy are required")
+	}
+
+	if req.Status != adminModel.VerificationStatusApproved && req.Status != adminModel.VerificationStatusRejected {
+		return nil, status.Errorf(codes.InvalidArgument, "Invalid status. Allowed values: 'approved', 'rejected'")
+	}
+
+	if req.Status == adminModel.VerificationStatusRejected && strings.TrimSpace(req.Reason) == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "A reason is required when rejecting a verification")
+	}
+
+	verification, err := s.AdminRepo.GetVendorVerification(ctx, req.VerificationId)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, status.Errorf(codes.NotFound, "Verification %s not found", req.VerificationId)
+	} else if err != nil {
+		return nil, status.Errorf(codes.Internal, "Failed to fetch verification: %v", err)
+	}
+
+	if !isOpenVerification(verification.Status) {
+		return nil, status.Errorf(codes.FailedPrecondition, "Verification %s has already been %s", req.VerificationId, verification.Status)
+	}
+
+	if req.Status == adminModel.VerificationStatusApproved {
+		if len(verification.Checks) == 0 {
+			return nil, status.Errorf(codes.FailedPrecondition, "At least one check must be recorded before approval")
+		}
+		for _, check := range verification.Checks {
+			if !check.Passed {
+				return nil, status.Errorf(codes.FailedPrecondition, "Check %s failed, verification cannot be approved", check.CheckType)
+			}
+		}
+	}
+
+	err = s.AdminRepo.DecideVendorVerification(ctx, req.VerificationId, req.Status, req.Reason, req.ReviewedBy)
+	if errors.Is(err, repository.ErrVerificationDecided) {
+		return nil, status.Errorf(codes.FailedPrecondition, "Verification %s has already been decided", req.VerificationId)
+	} else if err != nil {
+		return nil, status.Errorf(codes.Internal, "Failed to record verification decision: %v", err)
+	}
+
+	s.log.Info("Admin Service: vendor verification decided", req.VerificationId, req.Status, req.ReviewedBy)
+
+	return &pb.DecideVendorVerificationResponse{
+		Message: fmt.Sprintf("Verification has been %s", req.Status),
+	}, nil
+}
+
+// requireVerifiedVendor gates actions that are only allowed for vendors
+// whose latest verification has been approved. Vendors approved before
+// document verification existed have no verification at all; they pass
+// as long as users.status still says they were approved.
+func (s *AdminService) requireVerifiedVendor(ctx context.Context, vendorID string) error {
+	latest, err := s.AdminRepo.GetLatestVendorVerification(ctx, vendorID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return s.requireLegacyApproval(ctx, vendorID)
+	} else if err != nil {
+		return status.Errorf(codes.Internal, "Failed to fetch vendor verification: %v", err)
+	}
+
+	if latest.Status != adminModel.VerificationStatusApproved {
+		return status.Errorf(codes.FailedPrecondition, "Vendor %s is not verified, verification is %s", vendorID, latest.Status)
+	}
+
+	return nil
+}
+
+func (s *AdminService) requireLegacyApproval(ctx context.Context, vendorID string) error {
+	userStatus, err := s.AdminRepo.GetUserStatus(ctx, vendorID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return status.Errorf(codes.NotFound, "Vendor %s not found", vendorID)
+	} else if err != nil {
+		return status.Errorf(codes.Internal, "Failed to fetch vendor status: %v", err)
+	}
+
+	if userStatus != adminModel.VerificationStatusApproved {
+		return status.Errorf(codes.FailedPrecondition, "Vendor %s has not submitted verification documents", vendorID)
+	}
+
+	return nil
+}
+
+func isVerificationStatus(s string) bool {
+	switch s {
+	case adminModel.VerificationStatusPending, adminModel.VerificationStatusInReview,
+		adminModel.VerificationStatusApproved, adminModel.VerificationStatusRejected:
+		return true
+	}
+	return false
+}
+
+func isOpenVerification(s string) bool {
+	return s == adminModel.VerificationStatusPending || s == adminModel.VerificationStatusInReview
+}
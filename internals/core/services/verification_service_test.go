@@ -0,0 +1,66 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	adminModel "github.com/AthulKrishna2501/zyra-admin-service/internals/core/models"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"gorm.io/gorm"
+)
+
+func TestRequireVerifiedVendor(t *testing.T) {
+	tests := []struct {
+		name         string
+		verification *adminModel.VendorVerification
+		userStatus   string
+		want         codes.Code
+	}{
+		{
+			name:         "approved verification",
+			verification: &adminModel.VendorVerification{Status: adminModel.VerificationStatusApproved},
+			want:         codes.OK,
+		},
+		{
+			name:         "verification in review",
+			verification: &adminModel.VendorVerification{Status: adminModel.VerificationStatusInReview},
+			want:         codes.FailedPrecondition,
+		},
+		{
+			name:         "rejected verification overrides legacy approval",
+			verification: &adminModel.VendorVerification{Status: adminModel.VerificationStatusRejected},
+			userStatus:   adminModel.VerificationStatusApproved,
+			want:         codes.FailedPrecondition,
+		},
+		{
+			name:       "legacy approved vendor without verification",
+			userStatus: adminModel.VerificationStatusApproved,
+			want:       codes.OK,
+		},
+		{
+			name:       "unapproved vendor without verification",
+			userStatus: adminModel.VerificationStatusPending,
+			want:       codes.FailedPrecondition,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &stubRepo{
+				getLatestVendorVerification: func(context.Context, string) (*adminModel.VendorVerification, error) {
+					if tt.verification == nil {
+						return nil, gorm.ErrRecordNotFound
+					}
+					return tt.verification, nil
+				},
+				getUserStatus: func(context.Context, string) (string, error) { return tt.userStatus, nil },
+			}
+
+			err := newTestService(repo).requireVerifiedVendor(context.Background(), "vendor-1")
+			if got := status.Code(err); got != tt.want {
+				t.Errorf("code = %v, want %v (%v)", got, tt.want, err)
+			}
+		})
+	}
+}